@@ -1,5 +1,5 @@
 module github.com/golangplus/container
 
-go 1.14
+go 1.21
 
 require github.com/golangplus/testing v1.0.0
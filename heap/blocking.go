@@ -0,0 +1,176 @@
+package heap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Blocking operations after the queue is closed.
+var ErrClosed = errors.New("heap: queue closed")
+
+// Blocking is a goroutine-safe priority queue based on Heap. Pop blocks until
+// an element is available, and Push blocks while the queue is full if a
+// capacity is set. Use NewBlocking to create an instance.
+type Blocking[T any] struct {
+	mu       sync.Mutex
+	h        Heap[T]
+	capacity int
+	closed   bool
+	// Closed to wake up the waiting consumers and producers, respectively.
+	// nil if there are no waiters.
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// NewBlocking returns a *Blocking with a customized less func and the
+// capacity. If less is nil, the natural order of T is used. A non-positive
+// capacity means unbounded.
+func NewBlocking[T any](less func(x, y T) bool, capacity int) *Blocking[T] {
+	return &Blocking[T]{
+		h:        Heap[T]{less: less},
+		capacity: capacity,
+	}
+}
+
+// Len returns the number of elements in the current queue.
+func (q *Blocking[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.h.Len()
+}
+
+// Push inserts an element to the queue. If the queue is full, it blocks until
+// there is room, ctx is done or the queue is closed. It returns ErrClosed if
+// the queue is closed, or ctx.Err() if ctx is done.
+func (q *Blocking[T]) Push(ctx context.Context, x T) error {
+	q.mu.Lock()
+	for !q.closed && q.full() {
+		if q.notFull == nil {
+			q.notFull = make(chan struct{})
+		}
+		wait := q.notFull
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	q.push(x)
+	return nil
+}
+
+// TryPush inserts an element to the queue and returns true, or returns false
+// without blocking if the queue is full or closed.
+func (q *Blocking[T]) TryPush(x T) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed || q.full() {
+		return false
+	}
+	q.push(x)
+	return true
+}
+
+// Pop removes the top element from the queue and returns it. If the queue is
+// empty, it blocks until an element is pushed, ctx is done or the queue is
+// closed. Elements remaining in a closed queue can still be popped; ErrClosed
+// is returned when a closed queue is empty. ctx.Err() is returned if ctx is
+// done.
+func (q *Blocking[T]) Pop(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	for !q.closed && q.h.Len() == 0 {
+		if q.notEmpty == nil {
+			q.notEmpty = make(chan struct{})
+		}
+		wait := q.notEmpty
+		q.mu.Unlock()
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		q.mu.Lock()
+	}
+	defer q.mu.Unlock()
+
+	if q.h.Len() == 0 {
+		var zero T
+		return zero, ErrClosed
+	}
+	return q.pop(), nil
+}
+
+// PopTimeout is similar to Pop but waits at most d. It returns
+// context.DeadlineExceeded on timeout.
+func (q *Blocking[T]) PopTimeout(d time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	return q.Pop(ctx)
+}
+
+// TryPop removes the top element from the queue and returns it and true, or
+// returns false without blocking if the queue is empty.
+func (q *Blocking[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return q.pop(), true
+}
+
+// Close closes the queue and wakes up all waiting goroutines. Later pushes
+// fail with ErrClosed, and pops fail with ErrClosed once the queue is empty.
+// Closing a closed queue has no effect.
+func (q *Blocking[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	wake(&q.notEmpty)
+	wake(&q.notFull)
+}
+
+// full returns whether the queue reaches its capacity. q.mu must be held.
+func (q *Blocking[T]) full() bool {
+	return q.capacity > 0 && q.h.Len() >= q.capacity
+}
+
+// push pushes x and wakes up waiting consumers. q.mu must be held.
+func (q *Blocking[T]) push(x T) {
+	q.h.Push(x)
+	wake(&q.notEmpty)
+}
+
+// pop pops the top element and wakes up waiting producers. q.mu must be held.
+func (q *Blocking[T]) pop() T {
+	x := q.h.Pop()
+	wake(&q.notFull)
+	return x
+}
+
+// wake closes *ch, if not nil, to wake up the goroutines waiting on it.
+func wake(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
@@ -0,0 +1,166 @@
+package heap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golangplus/testing/assert"
+)
+
+// The tests in this file are meant to be run with the race detector as well:
+//   go test -race
+
+func TestBlocking(t *testing.T) {
+	q := NewBlocking[int](nil, 0)
+	ctx := context.Background()
+
+	_, ok := q.TryPop()
+	assert.False(t, "ok", ok)
+
+	assert.NoError(t, q.Push(ctx, 5))
+	assert.NoError(t, q.Push(ctx, 2))
+	assert.True(t, "TryPush", q.TryPush(3))
+	assert.Equal(t, "len", q.Len(), 3)
+
+	x, err := q.Pop(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "x", x, 2)
+	x, ok = q.TryPop()
+	assert.True(t, "ok", ok)
+	assert.Equal(t, "x", x, 3)
+	x, err = q.PopTimeout(time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, "x", x, 5)
+
+	_, err = q.PopTimeout(time.Millisecond)
+	assert.Equal(t, "err", err, context.DeadlineExceeded)
+}
+
+func TestBlocking_PopWaits(t *testing.T) {
+	q := NewBlocking(func(x, y string) bool {
+		return x > y
+	}, 0)
+	res := make(chan string)
+	go func() {
+		x, err := q.Pop(context.Background())
+		assert.NoError(t, err)
+		res <- x
+	}()
+	time.Sleep(time.Millisecond)
+	assert.NoError(t, q.Push(context.Background(), "a"))
+	assert.Equal(t, "x", <-res, "a")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(time.Millisecond)
+		cancel()
+	}()
+	_, err := q.Pop(ctx)
+	assert.Equal(t, "err", err, context.Canceled)
+}
+
+func TestBlocking_Close(t *testing.T) {
+	q := NewBlocking[int](nil, 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := q.Pop(context.Background())
+			assert.Equal(t, "err", err, ErrClosed)
+		}()
+	}
+	time.Sleep(time.Millisecond)
+	q.Close()
+	wg.Wait()
+	q.Close()
+
+	assert.Equal(t, "Push", q.Push(context.Background(), 1), ErrClosed)
+	assert.False(t, "TryPush", q.TryPush(1))
+
+	q = NewBlocking[int](nil, 0)
+	q.TryPush(1)
+	q.Close()
+	x, err := q.Pop(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "x", x, 1)
+	_, err = q.Pop(context.Background())
+	assert.Equal(t, "err", err, ErrClosed)
+}
+
+func TestBlocking_Capacity(t *testing.T) {
+	q := NewBlocking[int](nil, 2)
+	ctx := context.Background()
+	assert.NoError(t, q.Push(ctx, 3))
+	assert.NoError(t, q.Push(ctx, 1))
+	assert.False(t, "TryPush", q.TryPush(2))
+
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	assert.Equal(t, "Push", q.Push(timeout, 2), context.DeadlineExceeded)
+
+	done := make(chan struct{})
+	go func() {
+		assert.NoError(t, q.Push(ctx, 2))
+		close(done)
+	}()
+	time.Sleep(time.Millisecond)
+	x, _ := q.Pop(ctx)
+	assert.Equal(t, "x", x, 1)
+	<-done
+	assert.Equal(t, "len", q.Len(), 2)
+
+	// Close wakes up blocked producers.
+	go func() {
+		time.Sleep(time.Millisecond)
+		q.Close()
+	}()
+	assert.Equal(t, "Push", q.Push(ctx, 4), ErrClosed)
+}
+
+func TestBlocking_Concurrent(t *testing.T) {
+	const producers, consumers, n = 4, 4, 1000
+	q := NewBlocking[int](nil, 16)
+	ctx := context.Background()
+
+	var pwg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < n; i++ {
+				assert.NoError(t, q.Push(ctx, p*n+i))
+			}
+		}(p)
+	}
+
+	sums := make([]int, consumers)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for {
+				x, err := q.Pop(ctx)
+				if err == ErrClosed {
+					return
+				}
+				assert.NoError(t, err)
+				sums[c] += x
+			}
+		}(c)
+	}
+
+	pwg.Wait()
+	q.Close()
+	cwg.Wait()
+
+	total := 0
+	for _, s := range sums {
+		total += s
+	}
+	const m = producers * n
+	assert.Equal(t, "total", total, m*(m-1)/2)
+}
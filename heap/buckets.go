@@ -0,0 +1,89 @@
+package heap
+
+// Buckets is a bucket queue of ints in a bounded range [0, size), e.g., small
+// integer priorities. It has the same methods as Ints with the natural order,
+// and Push, Pop and Peek are O(1) amortized for monotone usage, O(size) in the
+// worst case. Use NewBuckets to create an instance.
+type Buckets struct {
+	// counts[x] is the number of x in the queue.
+	counts []int
+	// Every element is not less than cursor.
+	cursor int
+	n      int
+}
+
+// NewBuckets returns a *Buckets accepting ints in [0, size).
+func NewBuckets(size int) *Buckets {
+	return &Buckets{
+		counts: make([]int, size),
+		cursor: size,
+	}
+}
+
+// Len returns the number of elements in the current queue.
+func (b *Buckets) Len() int {
+	return b.n
+}
+
+// Push inserts an element to the queue. It panics if x is out of range.
+func (b *Buckets) Push(x int) {
+	if x < 0 || x >= len(b.counts) {
+		panic("heap: Buckets value out of range")
+	}
+	b.counts[x]++
+	b.n++
+	if x < b.cursor {
+		b.cursor = x
+	}
+}
+
+// Peek returns the minimum element. It panics if the queue is empty.
+func (b *Buckets) Peek() int {
+	if b.n == 0 {
+		panic(errEmpty)
+	}
+	for b.counts[b.cursor] == 0 {
+		b.cursor++
+	}
+	return b.cursor
+}
+
+// TryPeek returns the minimum element and true, or false if the queue is
+// empty.
+func (b *Buckets) TryPeek() (int, bool) {
+	if b.n == 0 {
+		return 0, false
+	}
+	return b.Peek(), true
+}
+
+// Pop removes the minimum element from the queue and returns it. It panics if
+// the queue is empty.
+func (b *Buckets) Pop() int {
+	x := b.Peek()
+	b.counts[x]--
+	b.n--
+	return x
+}
+
+// TryPop removes the minimum element from the queue and returns it and true,
+// or false if the queue is empty.
+func (b *Buckets) TryPop() (int, bool) {
+	if b.n == 0 {
+		return 0, false
+	}
+	return b.Pop(), true
+}
+
+// PopAll pops and returns all elements of the queue in reverse order.
+// The complexity is O(N+size), where N = b.Len().
+func (b *Buckets) PopAll() []int {
+	res := make([]int, 0, b.n)
+	for x := len(b.counts) - 1; x >= b.cursor && x >= 0; x-- {
+		for ; b.counts[x] > 0; b.counts[x]-- {
+			res = append(res, x)
+		}
+	}
+	b.n, b.cursor = 0, len(b.counts)
+	return res
+}
@@ -0,0 +1,100 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+// intQueue is the common method set of Ints, Buckets and Calendar.
+type intQueue interface {
+	Len() int
+	Push(x int)
+	Peek() int
+	Pop() int
+	PopAll() []int
+}
+
+var (
+	_ intQueue = (*Ints)(nil)
+	_ intQueue = (*Buckets)(nil)
+	_ intQueue = (*Calendar)(nil)
+)
+
+// testIntQueue checks q against random operations with values in [0, n).
+func testIntQueue(t *testing.T, q intQueue, n int) {
+	var expected []int
+	for i := 0; i < 2000; i++ {
+		if rand.Intn(3) == 0 && q.Len() > 0 {
+			sort.Ints(expected)
+			assert.Equal(t, "Peek", q.Peek(), expected[0])
+			assert.Equal(t, "Pop", q.Pop(), expected[0])
+			expected = expected[1:]
+		} else {
+			x := rand.Intn(n)
+			q.Push(x)
+			expected = append(expected, x)
+		}
+		assert.Equal(t, "Len", q.Len(), len(expected))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+	assert.Equal(t, "PopAll", q.PopAll(), expected)
+	assert.Equal(t, "Len", q.Len(), 0)
+}
+
+func TestBuckets(t *testing.T) {
+	b := NewBuckets(10)
+	assert.Equal(t, "len", b.Len(), 0)
+
+	b.Push(5)
+	b.Push(2)
+	b.Push(1)
+	b.Push(3)
+	b.Push(2)
+
+	assert.Equal(t, "len", b.Len(), 5)
+	assert.Equal(t, "peek", b.Peek(), 1)
+
+	res := []int{b.Pop(), b.Pop(), b.Pop()}
+	assert.Equal(t, "res", res, []int{1, 2, 2})
+	b.Push(0)
+	assert.Equal(t, "PopAll", b.PopAll(), []int{5, 3, 0})
+
+	_, ok := b.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = b.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { b.Pop() }), "heap: empty")
+	assert.Panic(t, "Push", func() {
+		b.Push(10)
+	})
+	assert.Panic(t, "Push", func() {
+		b.Push(-1)
+	})
+
+	b.Push(9)
+	x, ok := b.TryPop()
+	assert.Equal(t, "x", x, 9)
+	assert.True(t, "ok", ok)
+
+	testIntQueue(t, NewBuckets(256), 256)
+}
+
+func BenchmarkBuckets(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Intn(256)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q := NewBuckets(256)
+		for _, vl := range data {
+			q.Push(vl)
+		}
+		for q.Len() > 0 {
+			q.Pop()
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package heap
+
+// Calendar is a calendar queue of ints, e.g., time-based priorities. The value
+// range is divided into days of a fixed width, and days are mapped to a ring of
+// buckets, a year, with wrap-around. It has the same methods as Ints with the
+// natural order, and Push, Pop and Peek are O(1) amortized when the values
+// popped are mostly increasing and spread over about a year.
+// Use NewCalendar to create an instance.
+type Calendar struct {
+	buckets []Ints
+	width   int
+	// Every element is on or after day cur.
+	cur int
+	n   int
+}
+
+// NewCalendar returns a *Calendar with the number of buckets and the width of
+// a day, both of which must be positive.
+func NewCalendar(buckets, width int) *Calendar {
+	if buckets <= 0 || width <= 0 {
+		panic("heap: non-positive Calendar buckets or width")
+	}
+	return &Calendar{
+		buckets: make([]Ints, buckets),
+		width:   width,
+	}
+}
+
+// Len returns the number of elements in the current queue.
+func (c *Calendar) Len() int {
+	return c.n
+}
+
+// Push inserts an element to the queue.
+func (c *Calendar) Push(x int) {
+	d := c.day(x)
+	if c.n == 0 || d < c.cur {
+		c.cur = d
+	}
+	c.bucket(d).Push(x)
+	c.n++
+}
+
+// Peek returns the minimum element. It panics if the queue is empty.
+func (c *Calendar) Peek() int {
+	return c.next().Peek()
+}
+
+// TryPeek returns the minimum element and true, or false if the queue is
+// empty.
+func (c *Calendar) TryPeek() (int, bool) {
+	if c.n == 0 {
+		return 0, false
+	}
+	return c.Peek(), true
+}
+
+// Pop removes the minimum element from the queue and returns it. It panics if
+// the queue is empty.
+func (c *Calendar) Pop() int {
+	x := c.next().Pop()
+	c.n--
+	return x
+}
+
+// TryPop removes the minimum element from the queue and returns it and true,
+// or false if the queue is empty.
+func (c *Calendar) TryPop() (int, bool) {
+	if c.n == 0 {
+		return 0, false
+	}
+	return c.Pop(), true
+}
+
+// PopAll pops and returns all elements of the queue in reverse order.
+func (c *Calendar) PopAll() []int {
+	res := make([]int, c.n)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = c.Pop()
+	}
+	return res
+}
+
+// next moves c.cur to the day of the minimum element and returns its bucket.
+func (c *Calendar) next() *Ints {
+	if c.n == 0 {
+		panic(errEmpty)
+	}
+	// Scan a year from the current day.
+	for d := c.cur; d < c.cur+len(c.buckets); d++ {
+		if b := c.bucket(d); b.Len() > 0 && c.day(b.Peek()) == d {
+			c.cur = d
+			return b
+		}
+	}
+	// The queue is sparse, find the minimum directly.
+	var min *Ints
+	for i := range c.buckets {
+		if b := &c.buckets[i]; b.Len() > 0 && (min == nil || b.Peek() < min.Peek()) {
+			min = b
+		}
+	}
+	c.cur = c.day(min.Peek())
+	return min
+}
+
+// day returns the day of x.
+func (c *Calendar) day(x int) int {
+	d := x / c.width
+	if x%c.width < 0 {
+		d-- // round towards negative infinity
+	}
+	return d
+}
+
+// bucket returns the bucket of day d.
+func (c *Calendar) bucket(d int) *Ints {
+	i := d % len(c.buckets)
+	if i < 0 {
+		i += len(c.buckets)
+	}
+	return &c.buckets[i]
+}
@@ -0,0 +1,99 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestCalendar(t *testing.T) {
+	c := NewCalendar(4, 10)
+	assert.Equal(t, "len", c.Len(), 0)
+
+	c.Push(55)
+	c.Push(12)
+	c.Push(17)
+	c.Push(1000)
+	c.Push(-3)
+
+	assert.Equal(t, "len", c.Len(), 5)
+	assert.Equal(t, "peek", c.Peek(), -3)
+
+	res := []int{c.Pop(), c.Pop(), c.Pop()}
+	assert.Equal(t, "res", res, []int{-3, 12, 17})
+	c.Push(13)
+	assert.Equal(t, "PopAll", c.PopAll(), []int{1000, 55, 13})
+
+	_, ok := c.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = c.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { c.Pop() }), "heap: empty")
+	assert.Panic(t, "NewCalendar", func() {
+		NewCalendar(0, 1)
+	})
+
+	c.Push(7)
+	x, ok := c.TryPeek()
+	assert.Equal(t, "x", x, 7)
+	assert.True(t, "ok", ok)
+	x, ok = c.TryPop()
+	assert.Equal(t, "x", x, 7)
+	assert.True(t, "ok", ok)
+
+	testIntQueue(t, NewCalendar(16, 8), 1000)
+	testIntQueue(t, NewCalendar(3, 1), 1000)
+}
+
+func TestCalendar_Events(t *testing.T) {
+	// Simulate events which schedule later events.
+	c := NewCalendar(64, 4)
+	var h Ints
+	for i := 0; i < 100; i++ {
+		x := rand.Intn(100)
+		c.Push(x)
+		h.Push(x)
+	}
+	for i := 0; i < 10000; i++ {
+		x := c.Pop()
+		assert.Equal(t, "Pop", x, h.Pop())
+		next := x + rand.Intn(200)
+		c.Push(next)
+		h.Push(next)
+	}
+}
+
+func BenchmarkCalendar(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Intn(200)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := NewCalendar(256, 4)
+		for _, vl := range data[:1000] {
+			c.Push(vl)
+		}
+		for _, vl := range data {
+			c.Push(c.Pop() + vl)
+		}
+	}
+}
+
+func BenchmarkCalendar_Ints(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Intn(200)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h Ints
+		for _, vl := range data[:1000] {
+			h.Push(vl)
+		}
+		for _, vl := range data {
+			h.Push(h.Pop() + vl)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package heap
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers, so that time-based types like
+// DelayQueue can be tested with a FakeClock.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// AfterFunc waits for the duration to elapse and then calls f in its own
+	// goroutine. It returns a Timer that can be used to cancel the call.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer returned by Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the Timer from firing. It returns false if the timer has
+	// already fired or been stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock of the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// FakeClock is a Clock whose time only moves by Advance or Set, for
+// deterministic tests. Timer funcs are called synchronously by Advance or Set.
+// Use NewFakeClock to create an instance.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers Indexed[*fakeTimer]
+	// The seq of the next timer.
+	seq uint64
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	f     func()
+	// The Handle in clock.timers.
+	handle Handle
+	// For the FIFO order of timers with the same time.
+	seq     uint64
+	stopped bool
+}
+
+// NewFakeClock returns a *FakeClock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.timers.less = func(x, y *fakeTimer) bool {
+		if !x.at.Equal(y.at) {
+			return x.at.Before(y.at)
+		}
+		return x.seq < y.seq
+	}
+	return c
+}
+
+// Now returns the current fake time.
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// AfterFunc registers f to be called when the fake time reaches Now() + d.
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, at: c.now.Add(d), f: f, seq: c.seq}
+	c.seq++
+	t.handle = c.timers.Push(t)
+	return t
+}
+
+// Advance moves the fake time forward by d and calls the funcs of the timers
+// due, in the order of their times.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+// Set sets the fake time to now and calls the funcs of the timers due, in the
+// order of their times. The time never moves backward.
+func (c *FakeClock) Set(now time.Time) {
+	for {
+		c.mu.Lock()
+		if now.After(c.now) {
+			c.now = now
+		}
+		if c.timers.Len() == 0 || c.timers.Peek().at.After(c.now) {
+			c.mu.Unlock()
+			return
+		}
+		t := c.timers.Pop()
+		t.stopped = true
+		c.mu.Unlock()
+
+		t.f()
+	}
+}
+
+func (t *fakeTimer) Stop() bool {
+	c := t.clock
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	c.timers.Remove(t.handle)
+	return true
+}
@@ -0,0 +1,48 @@
+package heap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	assert.Equal(t, "Now", c.Now(), start)
+
+	var fired []string
+	c.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	c.AfterFunc(time.Second, func() { fired = append(fired, "a") })
+	c.AfterFunc(2*time.Second, func() { fired = append(fired, "c") })
+	stopped := c.AfterFunc(time.Second, func() { fired = append(fired, "x") })
+	assert.True(t, "Stop", stopped.Stop())
+	assert.False(t, "Stop", stopped.Stop())
+
+	c.Advance(500 * time.Millisecond)
+	assert.Equal(t, "fired", fired, []string(nil))
+	c.Advance(500 * time.Millisecond)
+	assert.Equal(t, "fired", fired, []string{"a"})
+
+	// Timers registered by timer funcs fire in the same Advance if due.
+	c.AfterFunc(time.Second, func() {
+		c.AfterFunc(0, func() { fired = append(fired, "d") })
+	})
+	c.Advance(time.Hour)
+	assert.Equal(t, "fired", fired, []string{"a", "b", "c", "d"})
+	assert.Equal(t, "Now", c.Now(), start.Add(time.Hour+time.Second))
+
+	c.Set(start)
+	assert.Equal(t, "Now", c.Now(), start.Add(time.Hour+time.Second))
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	assert.False(t, "Before", SystemClock.Now().Before(before))
+
+	done := make(chan struct{})
+	SystemClock.AfterFunc(time.Millisecond, func() { close(done) })
+	<-done
+	assert.True(t, "Stop", SystemClock.AfterFunc(time.Hour, func() {}).Stop())
+}
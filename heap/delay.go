@@ -0,0 +1,188 @@
+package heap
+
+import (
+	"sync"
+	"time"
+)
+
+// DelayHandle identifies an item scheduled in a DelayQueue. Unlike Handle, it
+// is never reused.
+type DelayHandle uint64
+
+type delayItem[T any] struct {
+	value T
+	at    time.Time
+	id    DelayHandle
+}
+
+// DelayQueue delivers items on a channel when their scheduled times arrive.
+// Items with the same time are delivered in the order of scheduling. It is
+// goroutine-safe. Use NewDelayQueue to create an instance.
+type DelayQueue[T any] struct {
+	mu      sync.Mutex
+	clock   Clock
+	h       Indexed[delayItem[T]]
+	handles map[DelayHandle]Handle
+	nextID  DelayHandle
+	closed  bool
+
+	c    chan T
+	wake chan struct{}
+	done chan struct{}
+}
+
+// NewDelayQueue returns a *DelayQueue based on clock, whose channel has the
+// buffer size. If clock is nil, SystemClock is used. Close should be called
+// to release the delivering goroutine.
+func NewDelayQueue[T any](clock Clock, buffer int) *DelayQueue[T] {
+	if clock == nil {
+		clock = SystemClock
+	}
+	q := &DelayQueue[T]{
+		clock:   clock,
+		handles: make(map[DelayHandle]Handle),
+		c:       make(chan T, buffer),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	q.h.less = func(x, y delayItem[T]) bool {
+		if !x.at.Equal(y.at) {
+			return x.at.Before(y.at)
+		}
+		return x.id < y.id
+	}
+	go q.deliver()
+	return q
+}
+
+// C returns the channel on which the items are delivered. It is closed after
+// Close is called.
+func (q *DelayQueue[T]) C() <-chan T {
+	return q.c
+}
+
+// Len returns the number of items scheduled but not delivered yet.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.h.Len()
+}
+
+// Schedule schedules item to be delivered at the time and returns its
+// DelayHandle. It panics if the queue is closed.
+func (q *DelayQueue[T]) Schedule(item T, at time.Time) DelayHandle {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		panic("heap: Schedule on a closed DelayQueue")
+	}
+	id := q.nextID
+	q.nextID++
+	q.handles[id] = q.h.Push(delayItem[T]{value: item, at: at, id: id})
+	q.signal()
+	return id
+}
+
+// Cancel cancels the delivery of the item with DelayHandle h. It returns false
+// if the item has been delivered or canceled.
+func (q *DelayQueue[T]) Cancel(h DelayHandle) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	hd, ok := q.handles[h]
+	if !ok {
+		return false
+	}
+	delete(q.handles, h)
+	q.h.Remove(hd)
+	q.signal()
+	return true
+}
+
+// Reschedule changes the delivery time of the item with DelayHandle h. It
+// returns false if the item has been delivered or canceled.
+func (q *DelayQueue[T]) Reschedule(h DelayHandle, at time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	hd, ok := q.handles[h]
+	if !ok {
+		return false
+	}
+	item := q.h.Get(hd)
+	item.at = at
+	q.h.Update(hd, item)
+	q.signal()
+	return true
+}
+
+// Close stops the delivery and closes the channel. Items not delivered are
+// dropped. Closing a closed queue has no effect.
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return
+	}
+	q.closed = true
+	close(q.done)
+}
+
+// signal wakes up the delivering goroutine.
+func (q *DelayQueue[T]) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// deliver delivers the due items until the queue is closed.
+func (q *DelayQueue[T]) deliver() {
+	defer close(q.c)
+
+	var timer Timer
+	for {
+		q.mu.Lock()
+		if timer != nil {
+			timer.Stop()
+			timer = nil
+		}
+		if q.h.Len() > 0 {
+			top := q.h.Peek()
+			if d := top.at.Sub(q.clock.Now()); d > 0 {
+				timer = q.clock.AfterFunc(d, q.signal)
+				// The timer is relative to the time AfterFunc is called. If
+				// the clock has advanced since Now, it fires after top.at, so
+				// check again.
+				if !q.clock.Now().Before(top.at) {
+					q.mu.Unlock()
+					continue
+				}
+			} else {
+				q.h.Pop()
+				delete(q.handles, top.id)
+				q.mu.Unlock()
+
+				select {
+				case q.c <- top.value:
+				case <-q.done:
+					return
+				}
+				continue
+			}
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.wake:
+		case <-q.done:
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package heap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golangplus/testing/assert"
+)
+
+// assertNotReady asserts that nothing is delivered on c.
+func assertNotReady[T any](t *testing.T, c <-chan T) {
+	t.Helper()
+	select {
+	case x := <-c:
+		t.Errorf("%v is delivered unexpectedly", x)
+	case <-time.After(time.Millisecond):
+	}
+}
+
+func TestDelayQueue(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	q := NewDelayQueue[string](clock, 0)
+	defer q.Close()
+
+	q.Schedule("b", start.Add(2*time.Second))
+	q.Schedule("a", start.Add(time.Second))
+	c := q.Schedule("c", start.Add(3*time.Second))
+	x := q.Schedule("x", start.Add(time.Second))
+	q.Schedule("b2", start.Add(2*time.Second))
+	assert.Equal(t, "Len", q.Len(), 5)
+
+	assert.True(t, "Cancel", q.Cancel(x))
+	assert.False(t, "Cancel", q.Cancel(x))
+	assertNotReady(t, q.C())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, "item", <-q.C(), "a")
+	assertNotReady(t, q.C())
+
+	assert.True(t, "Reschedule", q.Reschedule(c, start.Add(1500*time.Millisecond)))
+	clock.Advance(time.Second)
+	assert.Equal(t, "item", <-q.C(), "c")
+	assert.Equal(t, "item", <-q.C(), "b")
+	assert.Equal(t, "item", <-q.C(), "b2")
+	assert.False(t, "Reschedule", q.Reschedule(c, start))
+	assert.Equal(t, "Len", q.Len(), 0)
+
+	// Past times are delivered immediately.
+	q.Schedule("d", start)
+	assert.Equal(t, "item", <-q.C(), "d")
+}
+
+// advancingClock is a FakeClock advancing by step right before a timer is
+// armed, as if the clock advanced between reading Now and calling AfterFunc.
+type advancingClock struct {
+	*FakeClock
+	step time.Duration
+}
+
+func (c advancingClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.Advance(c.step)
+	return c.FakeClock.AfterFunc(d, f)
+}
+
+func TestDelayQueue_ClockAdvancing(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := advancingClock{NewFakeClock(start), time.Second}
+	q := NewDelayQueue[string](clock, 0)
+	defer q.Close()
+	// Let the delivering goroutine become idle, so that no other wake-up hides
+	// the late timer.
+	assertNotReady(t, q.C())
+
+	q.Schedule("a", start.Add(time.Second))
+	select {
+	case x := <-q.C():
+		assert.Equal(t, "item", x, "a")
+	case <-time.After(time.Second):
+		t.Fatal("an item due is not delivered")
+	}
+	assert.Equal(t, "Len", q.Len(), 0)
+}
+
+func TestDelayQueue_Close(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	q := NewDelayQueue[int](clock, 1)
+	q.Schedule(1, clock.Now().Add(time.Minute))
+	q.Close()
+	q.Close()
+
+	_, ok := <-q.C()
+	assert.False(t, "ok", ok)
+	assert.Panic(t, "Schedule", func() {
+		q.Schedule(2, clock.Now())
+	})
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	q := NewDelayQueue[int](nil, 0)
+	defer q.Close()
+
+	now := time.Now()
+	q.Schedule(2, now.Add(2*time.Millisecond))
+	q.Schedule(1, now.Add(time.Millisecond))
+	assert.Equal(t, "item", <-q.C(), 1)
+	assert.Equal(t, "item", <-q.C(), 2)
+	assert.False(t, "Before", time.Now().Before(now.Add(2*time.Millisecond)))
+}
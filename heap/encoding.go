@@ -0,0 +1,178 @@
+package heap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
+)
+
+// errBinary is returned when decoding invalid binary data.
+var errBinary = errors.New("heap: invalid binary data")
+
+// MarshalJSON implements json.Marshaler. The heap is encoded as a JSON array of
+// its elements in the order of the underlying array.
+func (h Heap[T]) MarshalJSON() ([]byte, error) {
+	if h.list == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(h.list)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It replaces the elements of the
+// heap with the decoded ones and re-establishes the heap ordering, so the input
+// does not need to be a valid heap.
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var list []T
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	h.init(list)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder.
+func (h Heap[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(h.list); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements gob.GobDecoder. Similar to UnmarshalJSON, the heap
+// ordering is re-established.
+func (h *Heap[T]) GobDecode(data []byte) error {
+	var list []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&list); err != nil {
+		return err
+	}
+	h.init(list)
+	return nil
+}
+
+// init replaces the elements of the heap with list and heapifies it. The
+// underlying array is kept if it is large enough, e.g., for the capacity of a
+// heap for TopNPush.
+func (h *Heap[T]) init(list []T) {
+	if len(list) <= cap(h.list) {
+		old := h.list
+		h.list = h.list[:len(list)]
+		copy(h.list, list)
+		if len(old) > len(list) {
+			clear(old[len(list):]) // remove the references in the tail
+		}
+	} else {
+		h.list = list
+	}
+	less, swap := h.funcs()
+	InitF(len(h.list), less, swap)
+	h.check()
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The heap is encoded as the
+// number of elements followed by the elements, all in varint encoding.
+func (h Ints) MarshalBinary() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(h.list)))
+	for _, x := range h.list {
+		data = binary.AppendVarint(data, int64(x))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The heap ordering is
+// re-established, so the input does not need to be a valid heap.
+func (h *Ints) UnmarshalBinary(data []byte) error {
+	n, data, err := decodeCount(data, 1)
+	if err != nil {
+		return err
+	}
+	list := make([]int, n)
+	for i := range list {
+		x, l := binary.Varint(data)
+		if l <= 0 || x != int64(int(x)) {
+			return errBinary
+		}
+		list[i], data = int(x), data[l:]
+	}
+	if len(data) > 0 {
+		return errBinary
+	}
+	h.init(list)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The heap is encoded as the
+// number of elements in varint encoding followed by the IEEE 754 bits of the
+// elements in little endian.
+func (h Float64s) MarshalBinary() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(h.list)))
+	for _, x := range h.list {
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(x))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The heap ordering is
+// re-established, so the input does not need to be a valid heap.
+func (h *Float64s) UnmarshalBinary(data []byte) error {
+	n, data, err := decodeCount(data, 8)
+	if err != nil {
+		return err
+	}
+	if len(data) != 8*n {
+		return errBinary
+	}
+	list := make([]float64, n)
+	for i := range list {
+		list[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:]))
+	}
+	h.init(list)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The heap is encoded as the
+// number of elements followed by the elements, each as its length and bytes,
+// with lengths in varint encoding.
+func (h Strings) MarshalBinary() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(len(h.list)))
+	for _, x := range h.list {
+		data = binary.AppendUvarint(data, uint64(len(x)))
+		data = append(data, x...)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. The heap ordering is
+// re-established, so the input does not need to be a valid heap.
+func (h *Strings) UnmarshalBinary(data []byte) error {
+	n, data, err := decodeCount(data, 1)
+	if err != nil {
+		return err
+	}
+	list := make([]string, n)
+	for i := range list {
+		l, k := binary.Uvarint(data)
+		if k <= 0 || l > uint64(len(data)-k) {
+			return errBinary
+		}
+		list[i], data = string(data[k:k+int(l)]), data[k+int(l):]
+	}
+	if len(data) > 0 {
+		return errBinary
+	}
+	h.init(list)
+	return nil
+}
+
+// decodeCount decodes the number of elements at the beginning of data, each of
+// which takes at least minSize bytes, and returns it with the rest of data.
+func decodeCount(data []byte, minSize int) (int, []byte, error) {
+	n, k := binary.Uvarint(data)
+	if k <= 0 || n > uint64(len(data)-k)/uint64(minSize) {
+		return 0, nil, errBinary
+	}
+	return int(n), data[k:], nil
+}
@@ -0,0 +1,177 @@
+package heap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestInts_Binary(t *testing.T) {
+	var h Ints
+	for _, x := range []int{5, -2, 1, math.MaxInt64, math.MinInt64} {
+		h.Push(x)
+	}
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+
+	var d Ints
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "PopAll", d.PopAll(), []int{math.MaxInt64, 5, 1, -2, math.MinInt64})
+
+	// An input not in heap order is heapified.
+	h = Ints{}
+	h.list = []int{5, 3, 1}
+	data, _ = h.MarshalBinary()
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "Pop", d.Pop(), 1)
+
+	for _, data := range [][]byte{nil, {3, 2}, {1, 2, 3}, {1, 0x80}} {
+		assert.Error(t, d.UnmarshalBinary(data))
+	}
+}
+
+func TestInts_BinaryTopN(t *testing.T) {
+	h := NewInts(nil, 3)
+	for _, x := range []int{5, 1, 9, 3} {
+		h.TopNPush(x)
+	}
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+
+	// Decoding keeps the underlying array of d for TopNPush.
+	d := NewInts(nil, 3)
+	d.TopNPush(100)
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "cap", cap(d.list), 4)
+	d.TopNPush(4)
+	assert.Equal(t, "TopNPopAll", d.TopNPopAll(), []int{9, 5, 4})
+
+	// A smaller input clears the tail of the underlying array.
+	d.PushN(1, 2, 3)
+	data, _ = NewInts(nil, 0).MarshalBinary()
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "len", d.Len(), 0)
+	assert.Equal(t, "tail", d.list[:3], []int{0, 0, 0})
+	assert.Error(t, d.UnmarshalBinary([]byte{1, 0x80}))
+	assert.Equal(t, "len", d.Len(), 0)
+}
+
+func TestFloat64s_Binary(t *testing.T) {
+	h := NewFloat64s(func(x, y float64) bool {
+		return x > y
+	}, 0)
+	h.Push(1.5)
+	h.Push(-2)
+	h.Push(math.Inf(1))
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+
+	d := NewFloat64s(func(x, y float64) bool {
+		return x > y
+	}, 0)
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "PopAll", d.PopAll(), []float64{-2, 1.5, math.Inf(1)})
+
+	for _, data := range [][]byte{nil, {1, 0}, {0, 0}} {
+		assert.Error(t, d.UnmarshalBinary(data))
+	}
+}
+
+func TestStrings_Binary(t *testing.T) {
+	var h Strings
+	h.Push("Elmo")
+	h.Push("")
+	h.Push("Abby")
+	data, err := h.MarshalBinary()
+	assert.NoError(t, err)
+
+	var d Strings
+	assert.NoError(t, d.UnmarshalBinary(data))
+	assert.Equal(t, "PopAll", d.PopAll(), []string{"Elmo", "Abby", ""})
+
+	for _, data := range [][]byte{nil, {1, 5, 'a'}, {1, 1, 'a', 'b'}, {2, 1, 'a'}} {
+		assert.Error(t, d.UnmarshalBinary(data))
+	}
+}
+
+func TestHeap_JSON(t *testing.T) {
+	var h Ints
+	data, err := json.Marshal(&h)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data), "[]")
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(3)
+	data, err = json.Marshal(&h)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data), "[2,5,3]")
+
+	var d Ints
+	assert.NoError(t, json.Unmarshal([]byte("[9, 4, 7, 1]"), &d))
+	assert.Equal(t, "PopAll", d.PopAll(), []int{9, 7, 4, 1})
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), &d))
+
+	s := struct {
+		Queue *Strings
+	}{Queue: NewStrings(nil, 0)}
+	assert.NoError(t, json.Unmarshal([]byte(`{"Queue": ["b", "c", "a"]}`), &s))
+	assert.Equal(t, "Pop", s.Queue.Pop(), "a")
+}
+
+func TestHeap_EncodeField(t *testing.T) {
+	// Heaps stored by value in a struct are encoded too.
+	type checkpoint struct {
+		Ints     Ints
+		Float64s Float64s
+		Strings  Strings
+	}
+	var c checkpoint
+	c.Ints.PushN(3, 1, 2)
+	c.Float64s.Push(0.5)
+	c.Strings.Push("a")
+
+	data, err := json.Marshal(c)
+	assert.NoError(t, err)
+	assert.Equal(t, "data", string(data), `{"Ints":[1,3,2],"Float64s":[0.5],"Strings":["a"]}`)
+	var d checkpoint
+	assert.NoError(t, json.Unmarshal(data, &d))
+	assert.Equal(t, "PopAll", d.Ints.PopAll(), []int{3, 2, 1})
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(c))
+	d = checkpoint{}
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&d))
+	assert.Equal(t, "PopAll", d.Ints.PopAll(), []int{3, 2, 1})
+	assert.Equal(t, "PopAll", d.Strings.PopAll(), []string{"a"})
+
+	var m encoding.BinaryMarshaler = c.Float64s
+	data, err = m.MarshalBinary()
+	assert.NoError(t, err)
+	var f Float64s
+	assert.NoError(t, f.UnmarshalBinary(data))
+	assert.Equal(t, "Pop", f.Pop(), 0.5)
+}
+
+func TestHeap_Gob(t *testing.T) {
+	var h Float64s
+	h.Push(5)
+	h.Push(2)
+	h.Push(3)
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(&h))
+	var d Float64s
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&d))
+	assert.Equal(t, "PopAll", d.PopAll(), []float64{5, 3, 2})
+
+	data, err := h.GobEncode()
+	assert.NoError(t, err)
+	var s Strings
+	assert.Error(t, s.GobDecode(data))
+}
@@ -0,0 +1,333 @@
+package heap
+
+import (
+	"cmp"
+)
+
+// FibonacciNode is an element in a Fibonacci heap, returned by Insert.
+type FibonacciNode[T any] struct {
+	value T
+	// Nodes of the same parent form a circular doubly linked list through left
+	// and right, so do the roots.
+	left, right   *FibonacciNode[T]
+	parent, child *FibonacciNode[T]
+	// The number of children.
+	degree int
+	// Whether the node has lost a child since it became a child itself.
+	mark bool
+}
+
+// Value returns the value of the node.
+func (n *FibonacciNode[T]) Value() T {
+	return n.value
+}
+
+// Fibonacci is a pointer based heap, which supports decreasing the value of a
+// node in O(1) amortized time. Insert, Min, Union and DecreaseKey are O(1)
+// amortized, and ExtractMin and Delete are O(log(N)) amortized. Use
+// NewFibonacci or NewFibonacciFunc to create an instance.
+//
+// The pointer to the zero value of Fibonacci[T] is a heap with the natural
+// order if T is a builtin integer, floating-point or string type.
+type Fibonacci[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	// min is the minimum root, or nil if the heap is empty.
+	min *FibonacciNode[T]
+	n   int
+
+	// Buffers reused by consolidate.
+	roots   []*FibonacciNode[T]
+	degrees []*FibonacciNode[T]
+}
+
+// NewFibonacci returns a *Fibonacci[T] which orders values on the natural
+// order.
+func NewFibonacci[T cmp.Ordered]() *Fibonacci[T] {
+	return NewFibonacciFunc(cmp.Less[T])
+}
+
+// NewFibonacciFunc returns a *Fibonacci[T] with a customized less func. If less
+// is nil, the natural order of T is used.
+func NewFibonacciFunc[T any](less func(x, y T) bool) *Fibonacci[T] {
+	return &Fibonacci[T]{less: less}
+}
+
+// Len returns the number of elements in the current heap.
+func (h *Fibonacci[T]) Len() int {
+	return h.n
+}
+
+// Insert inserts an element to the heap and returns its node, which can be
+// used for DecreaseKey and Delete later.
+// The complexity is O(1).
+func (h *Fibonacci[T]) Insert(x T) *FibonacciNode[T] {
+	n := &FibonacciNode[T]{value: x}
+	n.left, n.right = n, n
+	h.addRoot(n)
+	h.n++
+	return n
+}
+
+// Min returns the top most element. It panics if the heap is empty.
+func (h *Fibonacci[T]) Min() T {
+	if h.min == nil {
+		panic(errEmpty)
+	}
+	return h.min.value
+}
+
+// TryMin returns the top most element and true, or false if the heap is empty.
+func (h *Fibonacci[T]) TryMin() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+	return h.min.value, true
+}
+
+// ExtractMin removes the top element from the heap and returns it. It panics
+// if the heap is empty.
+// The complexity is O(log(N)) amortized.
+func (h *Fibonacci[T]) ExtractMin() T {
+	if h.min == nil {
+		panic(errEmpty)
+	}
+	z := h.min
+	// Move the children of z to the root list.
+	if c := z.child; c != nil {
+		for x := c; ; {
+			x.parent, x.mark = nil, false
+			if x = x.right; x == c {
+				break
+			}
+		}
+		splice(z, c)
+		z.child = nil
+	}
+	// Remove z from the root list.
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		unlink(z)
+		h.consolidate()
+	}
+	h.n--
+
+	z.left, z.right = nil, nil
+	return z.value
+}
+
+// TryExtractMin removes the top element from the heap and returns it and true,
+// or false if the heap is empty.
+func (h *Fibonacci[T]) TryExtractMin() (T, bool) {
+	if h.min == nil {
+		var zero T
+		return zero, false
+	}
+	return h.ExtractMin(), true
+}
+
+// PopAll extracts and returns all elements of the heap in reverse order.
+func (h *Fibonacci[T]) PopAll() []T {
+	res := make([]T, h.n)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = h.ExtractMin()
+	}
+	return res
+}
+
+// Each calls f on each element of the heap in pre-order of the trees, starting
+// from the top most one, until f returns false. The heap must not be modified
+// in f.
+func (h *Fibonacci[T]) Each(f func(x T) bool) {
+	if h.min == nil {
+		return
+	}
+	// Each stack entry is a node and the first node of its list, where the
+	// traversal of the list stops.
+	type entry struct{ n, first *FibonacciNode[T] }
+	stack := []entry{{h.min, h.min}}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(e.n.value) {
+			return
+		}
+		if e.n.right != e.first {
+			stack = append(stack, entry{e.n.right, e.first})
+		}
+		if e.n.child != nil {
+			stack = append(stack, entry{e.n.child, e.n.child})
+		}
+	}
+}
+
+// Values returns the elements of the heap in the order of Each.
+func (h *Fibonacci[T]) Values() []T {
+	res := make([]T, 0, h.n)
+	h.Each(func(x T) bool {
+		res = append(res, x)
+		return true
+	})
+	return res
+}
+
+// Sorted returns all elements of the heap in the order they would be
+// extracted, leaving the heap unchanged.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h *Fibonacci[T]) Sorted() []T {
+	return sortedCopy(h.Values(), h.lessFunc())
+}
+
+// DecreaseKey changes the value of node n, which must be in the heap, to x,
+// which must not be greater than its current value.
+// The complexity is O(1) amortized.
+func (h *Fibonacci[T]) DecreaseKey(n *FibonacciNode[T], x T) {
+	less := h.lessFunc()
+	if less(n.value, x) {
+		panic("heap: DecreaseKey with a greater value")
+	}
+	n.value = x
+	if p := n.parent; p != nil && less(x, p.value) {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	if less(x, h.min.value) {
+		h.min = n
+	}
+}
+
+// Delete removes node n, which must be in the heap, from the heap.
+// The complexity is O(log(N)) amortized.
+func (h *Fibonacci[T]) Delete(n *FibonacciNode[T]) {
+	if p := n.parent; p != nil {
+		h.cut(n)
+		h.cascadingCut(p)
+	}
+	// Extract n as if it were the minimum.
+	h.min = n
+	h.ExtractMin()
+}
+
+// Union moves all elements of other into h, leaving other empty. Both heaps
+// must have the same order.
+// The complexity is O(1).
+func (h *Fibonacci[T]) Union(other *Fibonacci[T]) {
+	if other == h || other.min == nil {
+		return
+	}
+	h.addRoot(other.min)
+	h.n += other.n
+	other.min, other.n = nil, 0
+}
+
+func (h *Fibonacci[T]) lessFunc() func(x, y T) bool {
+	if h.less == nil {
+		h.less = naturalLess[T]()
+	}
+	return h.less
+}
+
+// addRoot splices the list of n into the root list and updates h.min.
+func (h *Fibonacci[T]) addRoot(n *FibonacciNode[T]) {
+	if h.min == nil {
+		h.min = n
+		return
+	}
+	splice(h.min, n)
+	if h.lessFunc()(n.value, h.min.value) {
+		h.min = n
+	}
+}
+
+// consolidate links the roots of the same degree until all roots have distinct
+// degrees and resets h.min. h.min must be any root before calling.
+func (h *Fibonacci[T]) consolidate() {
+	less := h.lessFunc()
+
+	roots := h.roots[:0]
+	for x := h.min; ; {
+		roots = append(roots, x)
+		if x = x.right; x == h.min {
+			break
+		}
+	}
+	degrees := h.degrees
+	for _, x := range roots {
+		d := x.degree
+		for ; d < len(degrees) && degrees[d] != nil; d++ {
+			y := degrees[d]
+			if less(y.value, x.value) {
+				x, y = y, x
+			}
+			// y becomes a child of x.
+			unlink(y)
+			y.parent, y.mark = x, false
+			if x.child == nil {
+				x.child = y
+			} else {
+				splice(x.child, y)
+			}
+			x.degree++
+			degrees[d] = nil
+		}
+		for d >= len(degrees) {
+			degrees = append(degrees, nil)
+		}
+		degrees[d] = x
+	}
+	h.min = nil
+	for d, x := range degrees {
+		if x != nil {
+			if h.min == nil || less(x.value, h.min.value) {
+				h.min = x
+			}
+			degrees[d] = nil
+		}
+	}
+	clear(roots) // remove the references in the buffer
+	h.roots, h.degrees = roots, degrees
+}
+
+// cut moves node n, which has a parent, to the root list.
+func (h *Fibonacci[T]) cut(n *FibonacciNode[T]) {
+	p := n.parent
+	if p.child == n {
+		if n.right == n {
+			p.child = nil
+		} else {
+			p.child = n.right
+		}
+	}
+	unlink(n)
+	p.degree--
+	n.parent, n.mark = nil, false
+	splice(h.min, n)
+}
+
+// cascadingCut cuts the marked ancestors of a node which just lost its child
+// n, and marks the first unmarked non-root one.
+func (h *Fibonacci[T]) cascadingCut(n *FibonacciNode[T]) {
+	for p := n.parent; p != nil; n, p = p, p.parent {
+		if !n.mark {
+			n.mark = true
+			return
+		}
+		h.cut(n)
+	}
+}
+
+// splice joins the circular lists containing a and b, which must be distinct.
+func splice[T any](a, b *FibonacciNode[T]) {
+	ar, bl := a.right, b.left
+	a.right, b.left = b, a
+	bl.right, ar.left = ar, bl
+}
+
+// unlink removes n from its circular list, making it a single element list.
+func unlink[T any](n *FibonacciNode[T]) {
+	n.left.right, n.right.left = n.right, n.left
+	n.left, n.right = n, n
+}
@@ -0,0 +1,283 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestFibonacci(t *testing.T) {
+	var h Fibonacci[int]
+
+	assert.Equal(t, "len", h.Len(), 0)
+	_, ok := h.TryMin()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryExtractMin()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "ExtractMin", recovered(func() { h.ExtractMin() }), errEmpty)
+
+	h.Insert(5)
+	h.Insert(2)
+	h.Insert(1)
+	h.Insert(3)
+
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "min", h.Min(), 1)
+	assert.Equal(t, "Sorted", h.Sorted(), []int{1, 2, 3, 5})
+
+	res := []int{h.ExtractMin(), h.ExtractMin(), h.ExtractMin(), h.ExtractMin()}
+	assert.Equal(t, "res", res, []int{1, 2, 3, 5})
+
+	h.Insert(5)
+	h.Insert(2)
+	h.Insert(1)
+	h.Insert(3)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{5, 3, 2, 1})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestFibonacci_DecreaseKey(t *testing.T) {
+	h := NewFibonacci[int]()
+	nodes := make([]*FibonacciNode[int], 100)
+	for i := range nodes {
+		nodes[i] = h.Insert(1000 + i)
+	}
+	// Build trees of a larger degree.
+	assert.Equal(t, "ExtractMin", h.ExtractMin(), 1000)
+	for i := len(nodes) - 1; i > 0; i -= 2 {
+		h.DecreaseKey(nodes[i], i)
+	}
+	assert.Equal(t, "min", h.Min(), 1)
+	assert.Equal(t, "value", nodes[1].Value(), 1)
+
+	h.DecreaseKey(nodes[1], 1) // equal value is allowed
+	assert.Equal(t, "DecreaseKey", recovered(func() {
+		h.DecreaseKey(nodes[2], 2000)
+	}), "heap: DecreaseKey with a greater value")
+
+	var exp []int
+	for i := 1; i < len(nodes); i++ {
+		if i%2 == 1 {
+			exp = append(exp, i)
+		} else {
+			exp = append(exp, 1000+i)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(exp)))
+	assert.Equal(t, "Values", len(h.Values()), len(exp))
+	assert.Equal(t, "PopAll", h.PopAll(), exp)
+}
+
+func TestFibonacci_Delete(t *testing.T) {
+	h := NewFibonacci[int]()
+	nodes := make([]*FibonacciNode[int], 10)
+	for i := range nodes {
+		nodes[i] = h.Insert(i)
+	}
+	h.Delete(nodes[0]) // the minimum
+	assert.Equal(t, "min", h.Min(), 1)
+	h.Delete(nodes[9]) // in a tree after consolidation
+	h.Delete(nodes[5])
+	assert.Equal(t, "len", h.Len(), 7)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{8, 7, 6, 4, 3, 2, 1})
+}
+
+func TestFibonacci_Union(t *testing.T) {
+	h1, h2 := NewFibonacci[int](), NewFibonacci[int]()
+	h1.Insert(4)
+	h1.Insert(1)
+	h2.Insert(3)
+	h2.Insert(0)
+
+	h1.Union(h1)
+	h1.Union(NewFibonacci[int]())
+	h1.Union(h2)
+	assert.Equal(t, "len", h1.Len(), 4)
+	assert.Equal(t, "h2.len", h2.Len(), 0)
+	assert.Equal(t, "min", h1.Min(), 0)
+	assert.Equal(t, "PopAll", h1.PopAll(), []int{4, 3, 1, 0})
+
+	h2.Insert(2)
+	h1.Union(h2)
+	assert.Equal(t, "min", h1.Min(), 2)
+}
+
+func TestFibonacci_Random(t *testing.T) {
+	h := NewFibonacciFunc(func(x, y int) bool { return x > y })
+	// The lower 5 digits of a value is unique, so the extracted node is known.
+	live := map[*FibonacciNode[int]]bool{}
+	for i := 0; i < 10000; i++ {
+		switch rand.Intn(4) {
+		case 0, 1:
+			live[h.Insert(rand.Intn(1000)*100000+i)] = true
+		case 2:
+			for n := range live {
+				if rand.Intn(2) == 0 {
+					h.DecreaseKey(n, n.Value()+rand.Intn(100)*100000)
+				} else {
+					h.Delete(n)
+					delete(live, n)
+				}
+				break
+			}
+		case 3:
+			if h.Len() == 0 {
+				continue
+			}
+			mx := -1
+			for n := range live {
+				if n.Value() > mx {
+					mx = n.Value()
+				}
+			}
+			assert.Equal(t, "Min", h.Min(), mx)
+			for n := range live {
+				if n.Value() == mx {
+					h.ExtractMin()
+					delete(live, n)
+				}
+			}
+		}
+		assert.Equal(t, "len", h.Len(), len(live))
+	}
+	var exp []int
+	for n := range live {
+		exp = append(exp, n.Value())
+	}
+	sort.Ints(exp)
+	assert.Equal(t, "PopAll", h.PopAll(), exp)
+}
+
+// dijkstraFibonacci returns the distances from node 0 with a Fibonacci heap
+// and DecreaseKey.
+func dijkstraFibonacci(g [][]graphEdge) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	nodes := make([]*FibonacciNode[distNode], len(g))
+	h := NewFibonacciFunc(func(x, y distNode) bool {
+		return x.dist < y.dist
+	})
+	nodes[0] = h.Insert(distNode{0, 0})
+	for h.Len() > 0 {
+		x := h.ExtractMin()
+		dist[x.node] = x.dist
+		for _, e := range g[x.node] {
+			if dist[e.to] >= 0 {
+				continue
+			}
+			d := distNode{x.dist + e.weight, e.to}
+			if n := nodes[e.to]; n == nil {
+				nodes[e.to] = h.Insert(d)
+			} else if d.dist < n.Value().dist {
+				h.DecreaseKey(n, d)
+			}
+		}
+	}
+	return dist
+}
+
+// dijkstraIndexed returns the distances from node 0 with an Indexed heap and
+// Update, i.e., FixF for decrease-key.
+func dijkstraIndexed(g [][]graphEdge) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	handles := make([]Handle, len(g))
+	for i := range handles {
+		handles[i] = -1
+	}
+	h := NewIndexedFunc(func(x, y distNode) bool {
+		return x.dist < y.dist
+	}, 0)
+	handles[0] = h.Push(distNode{0, 0})
+	for h.Len() > 0 {
+		x := h.Pop()
+		dist[x.node] = x.dist
+		for _, e := range g[x.node] {
+			if dist[e.to] >= 0 {
+				continue
+			}
+			d := distNode{x.dist + e.weight, e.to}
+			if hd := handles[e.to]; hd < 0 {
+				handles[e.to] = h.Push(d)
+			} else if d.dist < h.Get(hd).dist {
+				h.Update(hd, d)
+			}
+		}
+	}
+	return dist
+}
+
+func TestDijkstraFibonacci(t *testing.T) {
+	g := randomGraph(1000, 4)
+	exp := dijkstraHeap(g)
+	assert.Equal(t, "Fibonacci", dijkstraFibonacci(g), exp)
+	assert.Equal(t, "Indexed", dijkstraIndexed(g), exp)
+}
+
+func BenchmarkFibonacci(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h Fibonacci[int]
+		for _, vl := range data {
+			h.Insert(vl)
+		}
+		for h.Len() > 0 {
+			h.ExtractMin()
+		}
+	}
+}
+
+func BenchmarkDijkstra_Fibonacci(b *testing.B) {
+	g := randomGraph(graphN, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraFibonacci(g)
+	}
+}
+
+func BenchmarkDijkstra_Indexed(b *testing.B) {
+	g := randomGraph(graphN, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraIndexed(g)
+	}
+}
+
+// The dense benchmarks run on graphs with many more edges than nodes, where
+// decrease-key dominates.
+
+const denseGraphN, denseDegree = 2000, 200
+
+func BenchmarkDijkstraDense_Fibonacci(b *testing.B) {
+	g := randomGraph(denseGraphN, denseDegree)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraFibonacci(g)
+	}
+}
+
+func BenchmarkDijkstraDense_Indexed(b *testing.B) {
+	g := randomGraph(denseGraphN, denseDegree)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraIndexed(g)
+	}
+}
+
+func BenchmarkDijkstraDense_Heap(b *testing.B) {
+	g := randomGraph(denseGraphN, denseDegree)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraHeap(g)
+	}
+}
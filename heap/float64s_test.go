@@ -52,3 +52,23 @@ func TestFloat64s_CustomLess(t *testing.T) {
 	h.Push(3)
 	assert.Equal(t, "PopAll", h.PopAll(), []float64{1, 2, 3, 5})
 }
+
+func TestFloat64s_Meld(t *testing.T) {
+	var h1, h2 Float64s
+	h1.Push(3)
+	h1.Push(1)
+	h2.Push(2)
+	h1.Meld(&h2)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+	assert.Equal(t, "PopAll", h1.PopAll(), []float64{3, 2, 1})
+}
+
+func TestFloat64s_TopN(t *testing.T) {
+	h := NewFloat64s(nil, 2)
+	for _, v := range []float64{5, 1, 9, 3} {
+		h.TopNPush(v)
+	}
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []float64{9, 5})
+
+	assert.Equal(t, "TopNFloat64s", TopNFloat64s([]float64{0.5, 0.1, 0.9, 0.3}, 3, nil), []float64{0.9, 0.5, 0.3})
+}
@@ -0,0 +1,454 @@
+package heap
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Heap is a heap for values of type T. Use New for types with a natural order,
+// or NewFunc to customize the less func.
+//
+// The pointer to the zero value of Heap[T] is a heap with the natural order if T
+// is a builtin integer, floating-point or string type. Otherwise, New or NewFunc
+// must be used to create an instance.
+type Heap[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	list []T
+	// Whether to verify the heap after every mutation.
+	checked bool
+	// Applied after an element is removed. nil means never shrinking.
+	shrink ShrinkPolicy
+	// The N of TopNPush, which is the initial capacity passed to NewFunc.
+	topN int
+	// Collects the stats of operations if not nil.
+	stats *StatsCollector
+
+	// Less and swap funcs on indexes of list, bound to bound. They are rebuilt
+	// when the Heap value is copied.
+	bound   *Heap[T]
+	lessIdx func(i, j int) bool
+	swapIdx func(i, j int)
+}
+
+// New returns a *Heap[T] which orders values on the natural order and has the
+// initial capacity.
+func New[T cmp.Ordered](cap int) *Heap[T] {
+	return NewFunc(cmp.Less[T], cap)
+}
+
+// NewFunc returns a *Heap[T] with a customized less func and the initial
+// capacity, which is also the N of TopNPush. If less is nil, the natural order
+// of T is used.
+func NewFunc[T any](less func(x, y T) bool, cap int) *Heap[T] {
+	h := &Heap[T]{less: less}
+	if cap > 0 {
+		// One more slot is reserved for TopNPush.
+		h.list = make([]T, 0, cap+1)
+		h.topN = cap
+	}
+	return h
+}
+
+// Len returns the number of elements in the current heap.
+func (h *Heap[T]) Len() int {
+	return len(h.list)
+}
+
+// Push inserts an element to the heap.
+func (h *Heap[T]) Push(x T) {
+	h.list = append(h.list, x)
+
+	less, swap := h.funcs()
+	PushLastF(len(h.list), less, swap)
+	h.check()
+}
+
+// Peek returns the top most element. It panics if the heap is empty.
+func (h *Heap[T]) Peek() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	return h.list[0]
+}
+
+// TryPeek returns the top most element and true, or false if the heap is empty.
+func (h *Heap[T]) TryPeek() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Peek(), true
+}
+
+// Pop removes the top element from the heap and returns it. It panics if the
+// heap is empty.
+func (h *Heap[T]) Pop() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	less, swap := h.funcs()
+	PopToLastF(len(h.list), less, swap)
+
+	res := h.removeLast()
+	h.check()
+	return res
+}
+
+// TryPop removes the top element from the heap and returns it and true, or
+// false if the heap is empty.
+func (h *Heap[T]) TryPop() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Pop(), true
+}
+
+// At returns the element at index i of the underlying array. The element at
+// index 0 is the top most element. It panics if i is out of range.
+func (h *Heap[T]) At(i int) T {
+	return h.list[i]
+}
+
+// Set replaces the element at index i with x and re-establishes the heap
+// ordering. It panics if i is out of range.
+// The complexity is O(log(N)), where N = h.Len().
+func (h *Heap[T]) Set(i int, x T) {
+	h.list[i] = x
+	h.Fix(i)
+}
+
+// Fix re-establishes the heap ordering after the element at index i has
+// changed, e.g., when its priority depends on data outside the heap.
+// The complexity is O(log(N)), where N = h.Len().
+func (h *Heap[T]) Fix(i int) {
+	less, swap := h.funcs()
+	FixF(len(h.list), less, swap, i)
+	h.check()
+}
+
+// Remove removes the element at index i from the heap and returns it. It
+// panics if i is out of range.
+// The complexity is O(log(N)), where N = h.Len().
+func (h *Heap[T]) Remove(i int) T {
+	_ = h.list[i] // bounds check
+	less, swap := h.funcs()
+	RemoveToLastF(len(h.list), less, swap, i)
+
+	res := h.removeLast()
+	h.check()
+	return res
+}
+
+// PopAll pops and returns all elements of the heap in reverse order.
+func (h *Heap[T]) PopAll() []T {
+	less, swap := h.funcs()
+	for n := h.Len(); n > 1; n-- {
+		PopToLastF(n, less, swap)
+	}
+	res := h.list
+	h.list = nil
+	return res
+}
+
+// Each calls f on each element of the heap in the order of the underlying
+// array, until f returns false. The heap must not be modified in f.
+func (h *Heap[T]) Each(f func(x T) bool) {
+	for _, x := range h.list {
+		if !f(x) {
+			break
+		}
+	}
+}
+
+// Values returns a copy of the underlying array of the heap, whose first
+// element is the top most one.
+func (h *Heap[T]) Values() []T {
+	return append([]T(nil), h.list...)
+}
+
+// Sorted returns all elements of the heap in the order they would be popped,
+// leaving the heap unchanged.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h *Heap[T]) Sorted() []T {
+	h.funcs()
+	return sortedCopy(h.list, h.less)
+}
+
+// Meld moves all elements of other into h, leaving other empty. Both heaps
+// must have the same order.
+// The complexity is O(min(K*log(N), N)), where K = other.Len() and N is the
+// size of the melded heap.
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h {
+		return
+	}
+	h.PushN(other.list...)
+	clear(other.list) // remove the references in other.list
+	other.list = other.list[:0]
+}
+
+// PushN inserts elements to the heap.
+// The complexity is O(min(K*log(N), N)), where K = len(xs) and N is the size of
+// the heap after pushing.
+func (h *Heap[T]) PushN(xs ...T) {
+	h.list = append(h.list, xs...)
+
+	less, swap := h.funcs()
+	PushLastNF(len(h.list), less, swap, len(xs))
+	h.check()
+}
+
+// TopNPush inserts an element to the heap if the heap does not reach its
+// capacity. Otherwise, if the top element is less then the new element
+// the top element is removed and the new one is inserted.
+// This method is used to generate a top N largest elements where N is
+// the initial capacity of the heap. Changing the capacity later, e.g., by Grow
+// or Shrink, does not change N.
+func (h *Heap[T]) TopNPush(x T) {
+	n := len(h.list)
+	if n < h.topN {
+		h.Push(x)
+		return
+	}
+	// Get the funcs before appending x to a temporary slot, which is not
+	// counted in the size of the heap by h.stats.
+	less, swap := h.funcs()
+	h.list = append(h.list, x)
+	if less(0, n) {
+		h.list[0] = x
+		FixF(n, less, swap, 0)
+	}
+	var zero T
+	h.list[n] = zero // remove the reference in h.list
+	h.list = h.list[:n]
+	h.check()
+}
+
+// TopNPopAll is similar to PopAll but keep the capacity of the heap
+// unchanged.
+func (h *Heap[T]) TopNPopAll() []T {
+	less, swap := h.funcs()
+	for n := h.Len(); n > 1; n-- {
+		PopToLastF(n, less, swap)
+	}
+	res := append([]T(nil), h.list...)
+	clear(h.list) // remove the references in h.list
+	h.list = h.list[:0]
+	return res
+}
+
+// TopN returns the n largest elements of values, according to less, in
+// descending order. If less is nil, the natural order of T is used.
+// The complexity is O(M*log(n)), where M = len(values).
+func TopN[T any](values []T, n int, less func(x, y T) bool) []T {
+	if n <= 0 {
+		return nil
+	}
+	h := NewFunc(less, n)
+	for _, v := range values {
+		h.TopNPush(v)
+	}
+	return h.TopNPopAll()
+}
+
+// ShrinkPolicy decides the new capacity of a heap's underlying array after an
+// element is removed, given the current length and capacity. Returning a value
+// not less than cap keeps the array.
+type ShrinkPolicy func(len, cap int) int
+
+// HalveWhenQuarterFull returns a ShrinkPolicy which halves the capacity when no
+// more than a quarter of it is used, while keeping it at least minCap.
+func HalveWhenQuarterFull(minCap int) ShrinkPolicy {
+	return func(len, cap int) int {
+		if cap/2 < minCap || len > cap/4 {
+			return cap
+		}
+		return cap / 2
+	}
+}
+
+// Clone returns a copy of the heap with the same order, capacity, checked mode
+// and shrink policy.
+// The complexity is O(N), where N = h.Len().
+func (h *Heap[T]) Clone() *Heap[T] {
+	c := &Heap[T]{
+		less:    h.less,
+		checked: h.checked,
+		shrink:  h.shrink,
+		topN:    h.topN,
+	}
+	if h.list != nil {
+		c.list = make([]T, len(h.list), cap(h.list))
+		copy(c.list, h.list)
+	}
+	return c
+}
+
+// Reset removes all elements from the heap while keeping the underlying array
+// for reuse.
+func (h *Heap[T]) Reset() {
+	clear(h.list) // remove the references in h.list
+	h.list = h.list[:0]
+}
+
+// Grow grows the capacity of the heap, if necessary, to guarantee space for
+// another n elements.
+func (h *Heap[T]) Grow(n int) {
+	if n < 0 {
+		panic("heap: negative Grow count")
+	}
+	if len(h.list)+n > cap(h.list) {
+		h.resize(len(h.list) + n)
+	}
+}
+
+// Shrink releases the unused capacity of the underlying array.
+func (h *Heap[T]) Shrink() {
+	if len(h.list) < cap(h.list) {
+		h.resize(len(h.list))
+	}
+}
+
+// SetShrinkPolicy sets the ShrinkPolicy applied after an element is removed by
+// Pop, TryPop or Remove. nil, the default, means never shrinking.
+func (h *Heap[T]) SetShrinkPolicy(p ShrinkPolicy) {
+	h.shrink = p
+}
+
+// resize reallocates the underlying array with capacity c.
+func (h *Heap[T]) resize(c int) {
+	h.list = withCap(h.list, c)
+}
+
+// withCap returns a copy of s in a newly allocated array with capacity c, which
+// must not be less than len(s).
+func withCap[S ~[]E, E any](s S, c int) S {
+	res := make(S, len(s), c)
+	copy(res, s)
+	return res
+}
+
+// SetChecked turns on or off the checked mode. In checked mode, the heap
+// property is verified after every mutation, which takes O(N) time, and a
+// violation, e.g., caused by an inconsistent less func, panics with a
+// diagnostic message. It is meant for debugging.
+func (h *Heap[T]) SetChecked(on bool) {
+	h.checked = on
+	h.check()
+}
+
+// SetStats sets the StatsCollector counting the operations on the heap. nil,
+// the default, disables collecting, which costs nothing. The collector is not
+// copied by Clone.
+func (h *Heap[T]) SetStats(c *StatsCollector) {
+	h.stats = c
+	h.bound = nil // rebuild the funcs
+}
+
+// check panics if h is in checked mode and the heap property is violated.
+func (h *Heap[T]) check() {
+	if !h.checked {
+		return
+	}
+	h.funcs() // makes h.less non-nil
+	// Not using the funcs wrapped by h.stats, which should not count checking.
+	less := func(i, j int) bool {
+		return h.less(h.list[i], h.list[j])
+	}
+	if i := VerifyF(len(h.list), less); i >= 0 {
+		p := (i - 1) / 2
+		panic(fmt.Sprintf("heap: heap property violated: element %d (%v) is less than its parent %d (%v)", i, h.list[i], p, h.list[p]))
+	}
+}
+
+// funcs returns the less and swap funcs on indexes of h.list, wrapped by
+// h.stats if it is not nil.
+func (h *Heap[T]) funcs() (less func(i, j int) bool, swap func(i, j int)) {
+	if h.bound != h {
+		if h.less == nil {
+			h.less = naturalLess[T]()
+		}
+		h.lessIdx = func(i, j int) bool {
+			return h.less(h.list[i], h.list[j])
+		}
+		h.swapIdx = func(i, j int) {
+			h.list[i], h.list[j] = h.list[j], h.list[i]
+		}
+		h.lessIdx, h.swapIdx = h.stats.wrap(h.lessIdx, h.swapIdx, false)
+		h.bound = h
+	}
+	if h.stats != nil {
+		h.stats.observeSize(len(h.list))
+	}
+	return h.lessIdx, h.swapIdx
+}
+
+// removeLast removes the last element of h.list and returns it.
+func (h *Heap[T]) removeLast() T {
+	n := len(h.list) - 1
+	res := h.list[n]
+	var zero T
+	h.list[n] = zero // remove the reference in h.list
+	h.list = h.list[:n]
+	if h.shrink != nil {
+		if c := h.shrink(n, cap(h.list)); c < cap(h.list) {
+			h.resize(max(c, n))
+		}
+	}
+	return res
+}
+
+// sortedCopy returns a copy of values sorted in ascending order of less.
+func sortedCopy[T any](values []T, less func(x, y T) bool) []T {
+	res := append([]T(nil), values...)
+	SortF(len(res), func(i, j int) bool {
+		return less(res[i], res[j])
+	}, func(i, j int) {
+		res[i], res[j] = res[j], res[i]
+	})
+	return res
+}
+
+// errEmpty is the panic message of peeking or popping an empty heap.
+const errEmpty = "heap: empty"
+
+// naturalLess returns the less func on the natural order of T, which must be
+// a builtin ordered type.
+func naturalLess[T any]() func(x, y T) bool {
+	var less any
+	switch any(*new(T)).(type) {
+	case int:
+		less = cmp.Less[int]
+	case int8:
+		less = cmp.Less[int8]
+	case int16:
+		less = cmp.Less[int16]
+	case int32:
+		less = cmp.Less[int32]
+	case int64:
+		less = cmp.Less[int64]
+	case uint:
+		less = cmp.Less[uint]
+	case uint8:
+		less = cmp.Less[uint8]
+	case uint16:
+		less = cmp.Less[uint16]
+	case uint32:
+		less = cmp.Less[uint32]
+	case uint64:
+		less = cmp.Less[uint64]
+	case uintptr:
+		less = cmp.Less[uintptr]
+	case float32:
+		less = cmp.Less[float32]
+	case float64:
+		less = cmp.Less[float64]
+	case string:
+		less = cmp.Less[string]
+	default:
+		panic("heap: no natural order for the element type, use New or NewFunc")
+	}
+	return less.(func(x, y T) bool)
+}
@@ -0,0 +1,321 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestHeap_New(t *testing.T) {
+	h := New[int](4)
+
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "peek", h.Peek(), 1)
+
+	res := []int{h.Pop(), h.Pop(), h.Pop(), h.Pop()}
+	assert.Equal(t, "res", res, []int{1, 2, 3, 5})
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{5, 3, 2, 1})
+}
+
+func TestHeap_ZeroValue(t *testing.T) {
+	var h Heap[uint16]
+	h.Push(3)
+	h.Push(1)
+	h.Push(2)
+	assert.Equal(t, "PopAll", h.PopAll(), []uint16{3, 2, 1})
+
+	type point struct{ x, y int }
+	var p Heap[point]
+	assert.Panic(t, "Push", func() {
+		p.Push(point{1, 2})
+	})
+}
+
+func TestHeap_NewFunc(t *testing.T) {
+	type item struct {
+		name     string
+		priority int
+	}
+	h := NewFunc(func(x, y item) bool {
+		return x.priority > y.priority
+	}, 0)
+	h.Push(item{"a", 1})
+	h.Push(item{"c", 3})
+	h.Push(item{"b", 2})
+
+	assert.Equal(t, "peek", h.Peek(), item{"c", 3})
+	assert.Equal(t, "Pop", h.Pop(), item{"c", 3})
+	assert.Equal(t, "Pop", h.Pop(), item{"b", 2})
+	assert.Equal(t, "Pop", h.Pop(), item{"a", 1})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestHeap_TopN(t *testing.T) {
+	h := New[int](3)
+	for _, v := range []int{5, 1, 9, 3, 7, 2} {
+		h.TopNPush(v)
+	}
+	assert.Equal(t, "len", h.Len(), 3)
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []int{9, 7, 5})
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.TopNPush(4)
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []int{4})
+
+	var zero Heap[int]
+	zero.TopNPush(1)
+	assert.Equal(t, "len", zero.Len(), 0)
+}
+
+func TestHeap_UnRef(t *testing.T) {
+	h := New[string](1)
+	h.TopNPush("Hello")
+	h.TopNPush("World")
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []string{"World"})
+	assert.Equal(t, "h.list[0]", h.list[:1][0], "")
+}
+
+func BenchmarkGenericIntHeap(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	b.StartTimer()
+	for i := 0; i < b.N; i++ {
+		h := New[int](0)
+		for _, vl := range data {
+			h.Push(vl)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func TestHeap_Meld(t *testing.T) {
+	h1, h2 := New[int](0), New[int](0)
+	h1.PushN(5, 1, 3)
+	h2.PushN(4, 2)
+	h1.Meld(h2)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+	h1.Meld(h1)
+	assert.Equal(t, "PopAll", h1.PopAll(), []int{5, 4, 3, 2, 1})
+
+	h2.Push(6)
+	assert.Equal(t, "h2.Peek()", h2.Peek(), 6)
+}
+
+func TestHeap_Checked(t *testing.T) {
+	priority := map[string]int{"a": 1, "b": 2, "c": 3}
+	h := NewFunc(func(x, y string) bool {
+		return priority[x] < priority[y]
+	}, 0)
+	h.SetChecked(true)
+	h.PushN("c", "b")
+	h.Push("a")
+	assert.Equal(t, "Pop", h.Pop(), "a")
+
+	// Changing the order without fixing the heap breaks it.
+	priority["c"] = 0
+	assert.Panic(t, "Push", func() {
+		h.Push("a")
+	})
+	assert.Panic(t, "SetChecked", func() {
+		h.SetChecked(true)
+	})
+	h.SetChecked(false)
+	h.Push("d")
+}
+
+func TestTopN(t *testing.T) {
+	type item struct {
+		name  string
+		score int
+	}
+	items := []item{{"a", 3}, {"b", 9}, {"c", 1}, {"d", 7}}
+	assert.Equal(t, "TopN", TopN(items, 2, func(x, y item) bool {
+		return x.score < y.score
+	}), []item{{"b", 9}, {"d", 7}})
+}
+
+func TestHeap_FixRemoveSet(t *testing.T) {
+	var h Heap[int]
+	h.PushN(1, 2, 5, 3, 9)
+	assert.Equal(t, "At(0)", h.At(0), 1)
+
+	h.Set(0, 4)
+	assert.Equal(t, "list", h.list, []int{2, 3, 5, 4, 9})
+
+	h.list[4] = 0
+	h.Fix(4)
+	assert.Equal(t, "Peek", h.Peek(), 0)
+
+	assert.Equal(t, "list", h.list, []int{0, 2, 5, 4, 3})
+
+	assert.Equal(t, "Remove", h.Remove(0), 0)
+	assert.Equal(t, "list", h.list, []int{2, 3, 5, 4})
+	assert.Equal(t, "Remove", h.Remove(3), 4)
+	assert.Panic(t, "Remove", func() {
+		h.Remove(10)
+	})
+	assert.Equal(t, "PopAll", h.PopAll(), []int{5, 3, 2})
+}
+
+// recovered returns the value recovered from the panic in f, or nil.
+func recovered(f func()) (v interface{}) {
+	defer func() {
+		v = recover()
+	}()
+	f()
+	return nil
+}
+
+func TestHeap_Empty(t *testing.T) {
+	var h Heap[int]
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Peek", recovered(func() { h.Peek() }), "heap: empty")
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), "heap: empty")
+
+	h.Push(1)
+	v, ok := h.TryPeek()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+	v, ok = h.TryPop()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestHeap_Iterate(t *testing.T) {
+	var h Heap[int]
+	h.PushN(5, 2, 1, 3)
+
+	var each []int
+	h.Each(func(x int) bool {
+		each = append(each, x)
+		return len(each) < 3
+	})
+	assert.Equal(t, "each", each, []int{1, 2, 5})
+	assert.Equal(t, "Values", h.Values(), []int{1, 2, 5, 3})
+	assert.Equal(t, "Sorted", h.Sorted(), []int{1, 2, 3, 5})
+	assert.Equal(t, "len", h.Len(), 4)
+
+	values := h.Values()
+	values[0] = 10
+	assert.Equal(t, "Peek", h.Peek(), 1)
+}
+
+func TestHeap_Clone(t *testing.T) {
+	h := New[int](3)
+	h.PushN(5, 2, 1)
+	c := h.Clone()
+	assert.Equal(t, "cap", cap(c.list), cap(h.list))
+
+	c.Push(0)
+	assert.Equal(t, "h.Peek()", h.Peek(), 1)
+	assert.Equal(t, "c.PopAll()", c.PopAll(), []int{5, 2, 1, 0})
+	assert.Equal(t, "h.PopAll()", h.PopAll(), []int{5, 2, 1})
+
+	var zero Heap[int]
+	zero.Clone().Push(1)
+	assert.Equal(t, "len", zero.Len(), 0)
+}
+
+func TestHeap_Capacity(t *testing.T) {
+	h := New[string](4)
+	h.PushN("b", "a", "c")
+	h.Reset()
+	assert.Equal(t, "len", h.Len(), 0)
+	assert.Equal(t, "cap", cap(h.list), 5)
+	assert.Equal(t, "h.list[0]", h.list[:1][0], "")
+
+	h.Grow(10)
+	assert.Equal(t, "cap", cap(h.list), 10)
+	h.Grow(3)
+	assert.Equal(t, "cap", cap(h.list), 10)
+	assert.Panic(t, "Grow", func() {
+		h.Grow(-1)
+	})
+
+	h.PushN("b", "a", "c")
+	h.Shrink()
+	assert.Equal(t, "cap", cap(h.list), 3)
+	assert.Equal(t, "Sorted", h.Sorted(), []string{"a", "b", "c"})
+}
+
+func TestHeap_TopNCapacity(t *testing.T) {
+	// Changing the capacity does not change the N of TopNPush.
+	h := New[int](4)
+	for _, v := range []int{5, 1, 9, 3} {
+		h.TopNPush(v)
+	}
+	h.Shrink()
+	h.TopNPush(7)
+	assert.Equal(t, "Sorted", h.Sorted(), []int{3, 5, 7, 9})
+
+	h.Grow(10)
+	h.TopNPush(8)
+	assert.Equal(t, "len", h.Len(), 4)
+
+	h = New[int](16)
+	h.SetShrinkPolicy(HalveWhenQuarterFull(1))
+	for i := 0; i < 16; i++ {
+		h.TopNPush(i)
+	}
+	for i := 0; i < 14; i++ {
+		h.Pop()
+	}
+	assert.Equal(t, "cap", cap(h.list), 4)
+	for i := 16; i < 32; i++ {
+		h.TopNPush(i)
+	}
+	assert.Equal(t, "len", h.Len(), 16)
+	assert.Equal(t, "Peek", h.Peek(), 16)
+
+	c := h.Clone()
+	c.TopNPush(40)
+	assert.Equal(t, "len", c.Len(), 16)
+	assert.Equal(t, "Peek", c.Peek(), 17)
+}
+
+func TestHeap_ShrinkPolicy(t *testing.T) {
+	var h Heap[int]
+	h.SetShrinkPolicy(HalveWhenQuarterFull(8))
+	h.Grow(64)
+	for i := 0; i < 64; i++ {
+		h.Push(i)
+	}
+	assert.Equal(t, "cap", cap(h.list), 64)
+	for i := 0; i < 48; i++ {
+		assert.Equal(t, "Pop", h.Pop(), i)
+	}
+	assert.Equal(t, "cap", cap(h.list), 32)
+	for i := 48; i < 62; i++ {
+		assert.Equal(t, "Pop", h.Pop(), i)
+	}
+	assert.Equal(t, "cap", cap(h.list), 8)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{63, 62})
+
+	h.SetShrinkPolicy(func(len, cap int) int {
+		return 0
+	})
+	h.PushN(3, 2, 1)
+	assert.Equal(t, "Remove", h.Remove(2), 3)
+	assert.Equal(t, "cap", cap(h.list), 2)
+}
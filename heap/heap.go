@@ -62,10 +62,23 @@ E.g., a heap with type T value can be implemented as follow:
         (*h)[i], (*h)[j] = (*h)[j], (*h)[i]
       })
     }
+
+Heap[T] is a ready-made heap of any type built on PushLastF/PopToLastF. Ints,
+Float64s, Strings and Interfaces are Heaps of the corresponding element type:
+
+    h := heap.NewFunc(func(x, y T) bool {
+      // return whether x < y
+    }, 0)
+    h.Push(x)
+    ...
+    value := h.Pop()
 */
 package heap
 
-import "sort"
+import (
+	"math/bits"
+	"sort"
+)
 
 // Init heapifies a non-empty array defined by the sort.Interface.
 // The complexity is O(N), where N = h.Len().
@@ -101,6 +114,28 @@ func PushLastF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
 	heapUp(Less, Swap, Len-1)
 }
 
+// PushLastN pushes the last k elements of the heap, which were not considered as
+// part of the heap, onto the heap. Depending on k and N, it either pushes them
+// one by one, in O(k*log(N)), or re-heapifies the whole array, in O(N),
+// whichever is cheaper. N = h.Len() includes the k elements.
+//
+// NOTE You need to append the elements to be pushed as the last elements before
+// calling to this method.
+func PushLastN(h sort.Interface, k int) {
+	PushLastNF(h.Len(), h.Less, h.Swap, k)
+}
+
+// Similar to PushLastN but with interface provided by funcs.
+func PushLastNF(Len int, Less func(i, j int) bool, Swap func(i, j int), k int) {
+	if k*bits.Len(uint(Len)) < Len {
+		for i := Len - k; i < Len; i++ {
+			heapUp(Less, Swap, i)
+		}
+		return
+	}
+	InitF(Len, Less, Swap)
+}
+
 // Pop removes the minimum element (according to Less) from the heap
 // and place it as the last element of the heap.
 // The complexity is O(log(N)), where N = h.Len().
@@ -160,6 +195,24 @@ func RemoveToLastF(Len int, Less func(i, j int) bool, Swap func(i, j int), i int
 	}
 }
 
+// Verify checks the heap property of the heap defined by the sort.Interface and
+// returns the first index whose element is less than its parent's, or -1 if
+// the heap is valid.
+// The complexity is O(N), where N = h.Len().
+func Verify(h sort.Interface) int {
+	return VerifyF(h.Len(), h.Less)
+}
+
+// Similar to Verify but with interface provided by funcs.
+func VerifyF(Len int, Less func(i, j int) bool) int {
+	for i := 1; i < Len; i++ {
+		if Less(i, (i-1)/2) {
+			return i
+		}
+	}
+	return -1
+}
+
 func heapUp(less func(i, j int) bool, swap func(i, j int), i int) {
 	for i > 0 {
 		p := (i - 1) / 2 // p is the parent of i
@@ -191,3 +244,119 @@ func heapDown(n int, less func(i, j int) bool, swap func(i, j int), i int) {
 		i = c // move to lower level
 	}
 }
+
+// InitD is similar to Init but for a d-ary heap, where each node has at most d
+// children. d must be at least 2. A larger d makes a shallower tree, which
+// speeds up PushLastD and improves the cache locality of large heaps.
+//
+// Heaps built by the D variants must be maintained with the D variants of the
+// same d.
+func InitD(h sort.Interface, d int) {
+	n := h.Len()
+	for i := (n - 2) / d; i >= 0; i-- {
+		heapDownD(d, n, h.Less, h.Swap, i)
+	}
+}
+
+// Similar to InitD but with interface provided by funcs.
+func InitDF(Len int, Less func(i, j int) bool, Swap func(i, j int), d int) {
+	for i := (Len - 2) / d; i >= 0; i-- {
+		heapDownD(d, Len, Less, Swap, i)
+	}
+}
+
+// PushLastD is similar to PushLast but for a d-ary heap.
+// The complexity is O(log_d(N)), where N = h.Len().
+func PushLastD(h sort.Interface, d int) {
+	heapUpD(d, h.Less, h.Swap, h.Len()-1)
+}
+
+// Similar to PushLastD but with interface provided by funcs.
+func PushLastDF(Len int, Less func(i, j int) bool, Swap func(i, j int), d int) {
+	heapUpD(d, Less, Swap, Len-1)
+}
+
+// PopToLastD is similar to PopToLast but for a d-ary heap.
+// The complexity is O(d*log_d(N)), where N = h.Len().
+func PopToLastD(h sort.Interface, d int) {
+	n1 := h.Len() - 1
+	h.Swap(0, n1)
+	heapDownD(d, n1, h.Less, h.Swap, 0)
+}
+
+// Similar to PopToLastD but with interface provided by funcs.
+func PopToLastDF(Len int, Less func(i, j int) bool, Swap func(i, j int), d int) {
+	Swap(0, Len-1)
+	heapDownD(d, Len-1, Less, Swap, 0)
+}
+
+// FixD is similar to Fix but for a d-ary heap.
+func FixD(h sort.Interface, d, index int) {
+	heapDownD(d, h.Len(), h.Less, h.Swap, index)
+	heapUpD(d, h.Less, h.Swap, index)
+}
+
+// Similar to FixD but with interface provided by funcs.
+func FixDF(Len int, Less func(i, j int) bool, Swap func(i, j int), d, index int) {
+	heapDownD(d, Len, Less, Swap, index)
+	heapUpD(d, Less, Swap, index)
+}
+
+// RemoveToLastD is similar to RemoveToLast but for a d-ary heap.
+//
+// NOTE You need to remove the last element after calling to this method.
+func RemoveToLastD(h sort.Interface, d, i int) {
+	n := h.Len() - 1
+	if n != i {
+		h.Swap(i, n)
+		heapDownD(d, n, h.Less, h.Swap, i)
+		heapUpD(d, h.Less, h.Swap, i)
+	}
+}
+
+// Similar to RemoveToLastD but with interface provided by funcs.
+func RemoveToLastDF(Len int, Less func(i, j int) bool, Swap func(i, j int), d, i int) {
+	n := Len - 1
+	if n != i {
+		Swap(i, n)
+		heapDownD(d, n, Less, Swap, i)
+		heapUpD(d, Less, Swap, i)
+	}
+}
+
+func heapUpD(d int, less func(i, j int) bool, swap func(i, j int), i int) {
+	for i > 0 {
+		p := (i - 1) / d // p is the parent of i
+		if !less(i, p) {
+			// h[p] <= h[i], already in order
+			break
+		}
+		swap(i, p)
+		i = p // move to upper level
+	}
+}
+
+func heapDownD(d, n int, less func(i, j int) bool, swap func(i, j int), i int) {
+	for {
+		l := d*i + 1 // first child
+		if l >= n || l < 0 {
+			break
+		}
+		e := l + d // end of the children
+		if e > n || e < 0 {
+			e = n
+		}
+		c := l // c is the minimum child
+		for r := l + 1; r < e; r++ {
+			if less(r, c) {
+				c = r
+			}
+		}
+		if !less(c, i) {
+			// h[i] <= h[c], already in order
+			break
+		}
+		swap(i, c)
+		i = c // move to lower level
+	}
+}
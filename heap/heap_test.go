@@ -2,6 +2,7 @@ package heap
 
 import (
 	"container/heap"
+	"fmt"
 	"math/rand"
 	"sort"
 	"testing"
@@ -245,3 +246,173 @@ func BenchmarkDataHeap_Pkg(b *testing.B) {
 		}
 	}
 }
+
+func TestInitD(t *testing.T) {
+	l := []int{5, 9, 1, 3, 2, 8, 7}
+	InitD(sort.IntSlice(l), 3)
+	assert.Equal(t, "l", l, []int{1, 2, 5, 3, 9, 8, 7})
+
+	l = []int{5, 9, 1, 3, 2, 8, 7}
+	InitDF(len(l), func(i, j int) bool { return l[i] < l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] }, 3)
+	assert.Equal(t, "l", l, []int{1, 2, 5, 3, 9, 8, 7})
+}
+
+func TestFixD(t *testing.T) {
+	l := []int{1, 2, 5, 3, 9, 8, 7}
+	l[0] = 6
+	FixD(sort.IntSlice(l), 3, 0)
+	assert.Equal(t, "l", l, []int{2, 6, 5, 3, 9, 8, 7})
+
+	l[4] = 0
+	FixDF(len(l), func(i, j int) bool { return l[i] < l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] }, 3, 4)
+	assert.Equal(t, "l", l, []int{0, 2, 5, 3, 6, 8, 7})
+}
+
+func TestRemoveToLastD(t *testing.T) {
+	l := []int{1, 2, 5, 3, 9, 8, 7}
+	RemoveToLastD(sort.IntSlice(l), 3, 0)
+	assert.Equal(t, "l", l, []int{2, 7, 5, 3, 9, 8, 1})
+
+	l = []int{1, 2, 5, 3, 9, 8, 7}
+	RemoveToLastDF(len(l), func(i, j int) bool { return l[i] < l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] }, 3, 1)
+	assert.Equal(t, "l", l, []int{1, 7, 5, 3, 9, 8, 2})
+}
+
+func TestDAryHeap(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		var l sort.IntSlice
+		for i := 0; i < 1000; i++ {
+			l = append(l, rand.Intn(100))
+			PushLastD(l, d)
+		}
+		last := -1
+		for len(l) > 0 {
+			PopToLastD(l, d)
+			cur := l[len(l)-1]
+			l = l[:len(l)-1]
+			if cur < last {
+				t.Errorf("d = %d: %d should be larger than %d", d, cur, last)
+			}
+			last = cur
+		}
+
+		l = make(sort.IntSlice, 1000)
+		for i := range l {
+			l[i] = rand.Int()
+		}
+		InitD(l, d)
+		last = -1
+		for n := len(l); n > 0; n-- {
+			PopToLastDF(n, l.Less, l.Swap, d)
+			if cur := l[n-1]; cur < last {
+				t.Errorf("d = %d: %d should be larger than %d", d, cur, last)
+			} else {
+				last = cur
+			}
+		}
+	}
+}
+
+type dAryIntHeap struct {
+	d    int
+	list sort.IntSlice
+}
+
+func (h *dAryIntHeap) Pop() int {
+	PopToLastDF(len(h.list), h.list.Less, h.list.Swap, h.d)
+	res := h.list[len(h.list)-1]
+	h.list = h.list[:len(h.list)-1]
+
+	return res
+}
+
+func (h *dAryIntHeap) Push(x int) {
+	h.list = append(h.list, x)
+	PushLastDF(len(h.list), h.list.Less, h.list.Swap, h.d)
+}
+
+func benchmarkDAryIntHeap(b *testing.B, d int) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := dAryIntHeap{d: d}
+		for _, vl := range data {
+			h.Push(vl)
+		}
+		for len(h.list) > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkDAryIntHeap_2(b *testing.B) { benchmarkDAryIntHeap(b, 2) }
+func BenchmarkDAryIntHeap_4(b *testing.B) { benchmarkDAryIntHeap(b, 4) }
+func BenchmarkDAryIntHeap_8(b *testing.B) { benchmarkDAryIntHeap(b, 8) }
+
+func BenchmarkDAryIntHeap_PushLast(b *testing.B) {
+	data := make(sort.IntSlice, M)
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprintf("d=%d", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				var l sort.IntSlice
+				for _, vl := range data {
+					l = append(l, vl)
+					PushLastD(l, d)
+				}
+			}
+		})
+	}
+	b.Run("binary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var l sort.IntSlice
+			for _, vl := range data {
+				l = append(l, vl)
+				PushLast(l)
+			}
+		}
+	})
+}
+
+func TestPushLastN(t *testing.T) {
+	for _, k := range []int{0, 1, 3, 50, 200} {
+		l := make(sort.IntSlice, 100)
+		for i := range l {
+			l[i] = rand.Intn(1000)
+		}
+		Init(l)
+		for i := 0; i < k; i++ {
+			l = append(l, rand.Intn(1000))
+		}
+		PushLastN(l, k)
+
+		last := -1
+		for n := len(l); n > 0; n-- {
+			PopToLastF(n, l.Less, l.Swap)
+			if cur := l[n-1]; cur < last {
+				t.Errorf("k = %d: %d should be larger than %d", k, cur, last)
+			} else {
+				last = cur
+			}
+		}
+	}
+
+	l := []int{1, 3, 2}
+	l = append(l, 0)
+	PushLastNF(len(l), func(i, j int) bool { return l[i] < l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] }, 1)
+	assert.Equal(t, "l", l, []int{0, 1, 2, 3})
+}
+
+func TestVerify(t *testing.T) {
+	assert.Equal(t, "Verify", Verify(sort.IntSlice{}), -1)
+	assert.Equal(t, "Verify", Verify(sort.IntSlice{1, 2, 5, 3, 9}), -1)
+	assert.Equal(t, "Verify", Verify(sort.IntSlice{1, 2, 5, 3, 0}), 4)
+
+	l := []int{1, 2, 0, 3, 0}
+	assert.Equal(t, "VerifyF", VerifyF(len(l), func(i, j int) bool { return l[i] < l[j] }), 2)
+}
@@ -0,0 +1,343 @@
+package heap
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// Handle identifies an element pushed into an Indexed heap. A Handle stays
+// valid until the element is popped or removed, and is never valid again after
+// that, even though its storage is reused by later Pushes. A valid Handle is
+// never negative.
+type Handle int
+
+// A Handle consists of the index of a slot in Indexed.slots in the low bits and
+// the generation of the slot in the high bits. The generation is bumped when
+// the slot is released, so that old Handles of the slot become invalid. It
+// wraps around only after the slot is reused 2^31 times (2^15 on 32-bit
+// platforms).
+const (
+	handleSlotBits = bits.UintSize / 2
+	handleSlotMask = 1<<handleSlotBits - 1
+	handleGenMask  = 1<<(bits.UintSize-handleSlotBits-1) - 1
+)
+
+func makeHandle(slot, gen int) Handle {
+	return Handle(gen<<handleSlotBits | slot)
+}
+
+func (hd Handle) slot() int {
+	return int(hd) & handleSlotMask
+}
+
+func (hd Handle) gen() int {
+	return int(hd) >> handleSlotBits
+}
+
+type handleSlot struct {
+	// The index in Indexed.list of the element of the slot, or -1 if the slot
+	// is free.
+	pos int
+	gen int
+}
+
+type indexedItem[T any] struct {
+	handle Handle
+	value  T
+}
+
+// Indexed is a heap whose elements can be updated or removed through the
+// Handles returned by Push, e.g., for the decrease-key operation in Dijkstra's
+// algorithm. Use NewIndexed or NewIndexedFunc to create an instance.
+//
+// The pointer to the zero value of Indexed[T] is a heap with the natural order
+// if T is a builtin integer, floating-point or string type.
+type Indexed[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	list []indexedItem[T]
+	// The slots of Handles, indexed by Handle.slot().
+	slots []handleSlot
+	// Indexes of the free slots in slots.
+	free []int
+	// Applied after an element is removed. nil means never shrinking.
+	shrink ShrinkPolicy
+
+	bound   *Indexed[T]
+	lessIdx func(i, j int) bool
+	swapIdx func(i, j int)
+}
+
+// NewIndexed returns an *Indexed[T] which orders values on the natural order and
+// has the initial capacity.
+func NewIndexed[T cmp.Ordered](cap int) *Indexed[T] {
+	return NewIndexedFunc(cmp.Less[T], cap)
+}
+
+// NewIndexedFunc returns an *Indexed[T] with a customized less func and the
+// initial capacity. If less is nil, the natural order of T is used.
+func NewIndexedFunc[T any](less func(x, y T) bool, cap int) *Indexed[T] {
+	h := &Indexed[T]{less: less}
+	if cap > 0 {
+		h.list = make([]indexedItem[T], 0, cap)
+		h.slots = make([]handleSlot, 0, cap)
+	}
+	return h
+}
+
+// Len returns the number of elements in the current heap.
+func (h *Indexed[T]) Len() int {
+	return len(h.list)
+}
+
+// Push inserts an element to the heap and returns its Handle.
+func (h *Indexed[T]) Push(x T) Handle {
+	var slot int
+	if n := len(h.free); n > 0 {
+		slot = h.free[n-1]
+		h.free = h.free[:n-1]
+	} else {
+		slot = len(h.slots)
+		h.slots = append(h.slots, handleSlot{})
+	}
+	h.slots[slot].pos = len(h.list)
+	hd := makeHandle(slot, h.slots[slot].gen)
+	h.list = append(h.list, indexedItem[T]{handle: hd, value: x})
+
+	less, swap := h.funcs()
+	PushLastF(len(h.list), less, swap)
+	return hd
+}
+
+// Peek returns the top most element. It panics if the heap is empty.
+func (h *Indexed[T]) Peek() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	return h.list[0].value
+}
+
+// TryPeek returns the top most element and true, or false if the heap is empty.
+func (h *Indexed[T]) TryPeek() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Peek(), true
+}
+
+// PeekHandle returns the Handle of the top most element. It panics if the heap
+// is empty.
+func (h *Indexed[T]) PeekHandle() Handle {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	return h.list[0].handle
+}
+
+// Pop removes the top element from the heap and returns it. It panics if the
+// heap is empty.
+func (h *Indexed[T]) Pop() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	less, swap := h.funcs()
+	PopToLastF(len(h.list), less, swap)
+
+	return h.removeLast()
+}
+
+// TryPop removes the top element from the heap and returns it and true, or
+// false if the heap is empty.
+func (h *Indexed[T]) TryPop() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Pop(), true
+}
+
+// PopAll pops and returns all elements of the heap in reverse order.
+func (h *Indexed[T]) PopAll() []T {
+	less, swap := h.funcs()
+	for n := h.Len(); n > 1; n-- {
+		PopToLastF(n, less, swap)
+	}
+	res := make([]T, len(h.list))
+	for i, item := range h.list {
+		res[i] = item.value
+		h.release(item.handle)
+	}
+	h.list = nil
+	return res
+}
+
+// Each calls f on each element of the heap and its Handle in the order of the
+// underlying array, until f returns false. The heap must not be modified in f.
+func (h *Indexed[T]) Each(f func(hd Handle, x T) bool) {
+	for _, item := range h.list {
+		if !f(item.handle, item.value) {
+			break
+		}
+	}
+}
+
+// Values returns a copy of the values in the underlying array of the heap,
+// whose first element is the top most one.
+func (h *Indexed[T]) Values() []T {
+	res := make([]T, len(h.list))
+	for i, item := range h.list {
+		res[i] = item.value
+	}
+	return res
+}
+
+// Sorted returns all values of the heap in the order they would be popped,
+// leaving the heap unchanged.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h *Indexed[T]) Sorted() []T {
+	h.funcs()
+	return sortedCopy(h.Values(), h.less)
+}
+
+// Contains returns whether the element with Handle hd is still in the heap.
+func (h *Indexed[T]) Contains(hd Handle) bool {
+	if hd < 0 {
+		return false
+	}
+	slot := hd.slot()
+	return slot < len(h.slots) && h.slots[slot].pos >= 0 && h.slots[slot].gen == hd.gen()
+}
+
+// Get returns the value of the element with Handle hd. It panics if the heap
+// does not contain the element.
+func (h *Indexed[T]) Get(hd Handle) T {
+	return h.list[h.index(hd)].value
+}
+
+// Update changes the value of the element with Handle hd and re-establishes the
+// heap ordering. It panics if the heap does not contain the element.
+// The complexity is O(log(N)), where N = h.Len().
+func (h *Indexed[T]) Update(hd Handle, x T) {
+	i := h.index(hd)
+	h.list[i].value = x
+
+	less, swap := h.funcs()
+	FixF(len(h.list), less, swap, i)
+}
+
+// Remove removes the element with Handle hd from the heap and returns its
+// value. It panics if the heap does not contain the element.
+// The complexity is O(log(N)), where N = h.Len().
+func (h *Indexed[T]) Remove(hd Handle) T {
+	i := h.index(hd)
+
+	less, swap := h.funcs()
+	RemoveToLastF(len(h.list), less, swap, i)
+
+	return h.removeLast()
+}
+
+// Clone returns a copy of the heap with the same order, capacity and shrink
+// policy. The Handles of the elements in h are also valid in the copy.
+// The complexity is O(M), where M is the maximum number of elements ever in h.
+func (h *Indexed[T]) Clone() *Indexed[T] {
+	c := &Indexed[T]{less: h.less, shrink: h.shrink}
+	if h.list != nil {
+		c.list = withCap(h.list, cap(h.list))
+	}
+	if h.slots != nil {
+		c.slots = withCap(h.slots, cap(h.slots))
+	}
+	if h.free != nil {
+		c.free = withCap(h.free, cap(h.free))
+	}
+	return c
+}
+
+// Reset removes all elements from the heap while keeping the underlying arrays
+// for reuse. All Handles become invalid.
+// The complexity is O(N), where N = h.Len().
+func (h *Indexed[T]) Reset() {
+	for _, item := range h.list {
+		h.release(item.handle)
+	}
+	clear(h.list) // remove the references in h.list
+	h.list = h.list[:0]
+}
+
+// Grow grows the capacity of the heap, if necessary, to guarantee space for
+// another n elements.
+func (h *Indexed[T]) Grow(n int) {
+	if n < 0 {
+		panic("heap: negative Grow count")
+	}
+	if len(h.list)+n > cap(h.list) {
+		h.list = withCap(h.list, len(h.list)+n)
+	}
+}
+
+// Shrink releases the unused capacity of the underlying array.
+func (h *Indexed[T]) Shrink() {
+	if len(h.list) < cap(h.list) {
+		h.list = withCap(h.list, len(h.list))
+	}
+}
+
+// SetShrinkPolicy sets the ShrinkPolicy applied after an element is removed by
+// Pop, TryPop or Remove. nil, the default, means never shrinking.
+func (h *Indexed[T]) SetShrinkPolicy(p ShrinkPolicy) {
+	h.shrink = p
+}
+
+// index returns the index in h.list of the element with Handle hd.
+func (h *Indexed[T]) index(hd Handle) int {
+	if !h.Contains(hd) {
+		panic("heap: invalid handle")
+	}
+	return h.slots[hd.slot()].pos
+}
+
+// funcs returns the less and swap funcs on indexes of h.list. swap keeps the
+// positions in h.slots in sync.
+func (h *Indexed[T]) funcs() (less func(i, j int) bool, swap func(i, j int)) {
+	if h.bound != h {
+		if h.less == nil {
+			h.less = naturalLess[T]()
+		}
+		h.lessIdx = func(i, j int) bool {
+			return h.less(h.list[i].value, h.list[j].value)
+		}
+		h.swapIdx = func(i, j int) {
+			h.list[i], h.list[j] = h.list[j], h.list[i]
+			h.slots[h.list[i].handle.slot()].pos = i
+			h.slots[h.list[j].handle.slot()].pos = j
+		}
+		h.bound = h
+	}
+	return h.lessIdx, h.swapIdx
+}
+
+// removeLast removes the last element of h.list, releases its Handle and
+// returns its value.
+func (h *Indexed[T]) removeLast() T {
+	n := len(h.list) - 1
+	item := h.list[n]
+	h.list[n] = indexedItem[T]{} // remove the reference in h.list
+	h.list = h.list[:n]
+
+	h.release(item.handle)
+	if h.shrink != nil {
+		if c := h.shrink(n, cap(h.list)); c < cap(h.list) {
+			h.list = withCap(h.list, max(c, n))
+		}
+	}
+	return item.value
+}
+
+// release frees the slot of Handle hd and invalidates hd.
+func (h *Indexed[T]) release(hd Handle) {
+	slot := hd.slot()
+	h.slots[slot] = handleSlot{pos: -1, gen: (hd.gen() + 1) & handleGenMask}
+	h.free = append(h.free, slot)
+}
@@ -0,0 +1,212 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestIndexed(t *testing.T) {
+	var h Indexed[int]
+
+	a := h.Push(5)
+	b := h.Push(2)
+	c := h.Push(8)
+	d := h.Push(3)
+
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "peek", h.Peek(), 2)
+	assert.Equal(t, "PeekHandle", h.PeekHandle(), b)
+	assert.True(t, "Contains(c)", h.Contains(c))
+	assert.Equal(t, "Get(c)", h.Get(c), 8)
+
+	h.Update(c, 1)
+	assert.Equal(t, "PeekHandle", h.PeekHandle(), c)
+	h.Update(c, 9)
+	assert.Equal(t, "PeekHandle", h.PeekHandle(), b)
+
+	assert.Equal(t, "Remove(d)", h.Remove(d), 3)
+	assert.False(t, "Contains(d)", h.Contains(d))
+	assert.False(t, "Contains(-1)", h.Contains(-1))
+	assert.False(t, "Contains(100)", h.Contains(100))
+	assert.Panic(t, "Update(d)", func() {
+		h.Update(d, 1)
+	})
+
+	assert.Equal(t, "Pop", h.Pop(), 2)
+	assert.False(t, "Contains(b)", h.Contains(b))
+	assert.Equal(t, "Get(a)", h.Get(a), 5)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{9, 5})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestIndexed_Random(t *testing.T) {
+	h := NewIndexedFunc(func(x, y int) bool {
+		return x > y
+	}, 10)
+	values := make(map[Handle]int)
+	for i := 0; i < 1000; i++ {
+		switch op := rand.Intn(4); {
+		case op < 2 || len(values) == 0:
+			v := rand.Intn(1000)
+			values[h.Push(v)] = v
+		case op == 2:
+			for hd := range values {
+				v := rand.Intn(1000)
+				h.Update(hd, v)
+				values[hd] = v
+				break
+			}
+		default:
+			for hd, v := range values {
+				assert.Equal(t, "Remove", h.Remove(hd), v)
+				delete(values, hd)
+				break
+			}
+		}
+		assert.Equal(t, "len", h.Len(), len(values))
+		for hd, v := range values {
+			assert.Equal(t, "Get", h.Get(hd), v)
+		}
+	}
+	var expected []int
+	for _, v := range values {
+		expected = append(expected, v)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+	for _, v := range expected {
+		assert.Equal(t, "Pop", h.Pop(), v)
+	}
+}
+
+func TestIndexed_Dijkstra(t *testing.T) {
+	// edges[u] lists (v, weight) pairs.
+	edges := [][][2]int{
+		{{1, 7}, {2, 9}, {5, 14}},
+		{{0, 7}, {2, 10}, {3, 15}},
+		{{0, 9}, {1, 10}, {3, 11}, {5, 2}},
+		{{1, 15}, {2, 11}, {4, 6}},
+		{{3, 6}, {5, 9}},
+		{{0, 14}, {2, 2}, {4, 9}},
+	}
+	dist := []int{0, -1, -1, -1, -1, -1}
+	handles := make([]Handle, len(dist))
+	h := NewIndexedFunc(func(x, y int) bool {
+		return dist[x] < dist[y]
+	}, len(dist))
+	handles[0] = h.Push(0)
+	for h.Len() > 0 {
+		u := h.Pop()
+		for _, e := range edges[u] {
+			v, d := e[0], dist[u]+e[1]
+			if dist[v] < 0 {
+				dist[v] = d
+				handles[v] = h.Push(v)
+			} else if d < dist[v] && h.Contains(handles[v]) {
+				dist[v] = d
+				h.Update(handles[v], v)
+			}
+		}
+	}
+	assert.Equal(t, "dist", dist, []int{0, 7, 9, 20, 20, 11})
+}
+
+func TestIndexed_Empty(t *testing.T) {
+	var h Indexed[int]
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), "heap: empty")
+	assert.Equal(t, "PeekHandle", recovered(func() { h.PeekHandle() }), "heap: empty")
+
+	h.Push(1)
+	v, ok := h.TryPop()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+}
+
+func TestIndexed_Iterate(t *testing.T) {
+	var h Indexed[int]
+	a := h.Push(5)
+	h.Push(2)
+	h.Push(1)
+
+	handles := make(map[Handle]int)
+	h.Each(func(hd Handle, x int) bool {
+		handles[hd] = x
+		return true
+	})
+	assert.Equal(t, "len(handles)", len(handles), 3)
+	assert.Equal(t, "handles[a]", handles[a], 5)
+	assert.Equal(t, "Values", h.Values(), []int{1, 5, 2})
+	assert.Equal(t, "Sorted", h.Sorted(), []int{1, 2, 5})
+}
+
+func TestIndexed_Capacity(t *testing.T) {
+	h := NewIndexed[int](0)
+	a := h.Push(5)
+	b := h.Push(2)
+
+	c := h.Clone()
+	c.Update(a, 1)
+	assert.Equal(t, "h.Get(a)", h.Get(a), 5)
+	assert.Equal(t, "c.Get(a)", c.Get(a), 1)
+	assert.Equal(t, "c.Pop", c.Pop(), 1)
+	assert.Equal(t, "h.Peek", h.Peek(), 2)
+
+	h.Grow(10)
+	assert.Equal(t, "cap", cap(h.list), 12)
+	assert.Panic(t, "Grow", func() {
+		h.Grow(-1)
+	})
+	h.Shrink()
+	assert.Equal(t, "cap", cap(h.list), 2)
+
+	h.SetShrinkPolicy(func(len, cap int) int {
+		return 0
+	})
+	assert.Equal(t, "Remove", h.Remove(b), 2)
+	assert.Equal(t, "cap", cap(h.list), 1)
+
+	h.Reset()
+	assert.Equal(t, "len", h.Len(), 0)
+	assert.False(t, "Contains(a)", h.Contains(a))
+	d := h.Push(3)
+	assert.NotEqual(t, "Push", d, a)
+	assert.False(t, "Contains(a)", h.Contains(a))
+	assert.True(t, "Contains(d)", h.Contains(d))
+}
+
+func TestIndexed_StaleHandle(t *testing.T) {
+	h := NewIndexed[string](0)
+	a := h.Push("a")
+	assert.Equal(t, "Pop", h.Pop(), "a")
+	b := h.Push("b")
+	assert.NotEqual(t, "b", b, a)
+	assert.True(t, "b >= 0", b >= 0)
+
+	// a is not valid again even though its storage is reused by b.
+	assert.False(t, "Contains(a)", h.Contains(a))
+	assert.Equal(t, "Remove", recovered(func() { h.Remove(a) }), "heap: invalid handle")
+	assert.Equal(t, "Get", recovered(func() { h.Get(a) }), "heap: invalid handle")
+	assert.Equal(t, "Update", recovered(func() { h.Update(a, "x") }), "heap: invalid handle")
+	assert.Equal(t, "Get(b)", h.Get(b), "b")
+
+	c := h.Push("c")
+	assert.Equal(t, "Remove", h.Remove(b), "b")
+	assert.False(t, "Contains(b)", h.Contains(b))
+	assert.True(t, "Contains(c)", h.Contains(c))
+
+	// PopAll invalidates all Handles.
+	h.PopAll()
+	assert.False(t, "Contains(c)", h.Contains(c))
+	d := h.Push("d")
+	assert.False(t, "Contains(c)", h.Contains(c))
+	assert.False(t, "Contains(b)", h.Contains(b))
+	assert.Equal(t, "Peek", h.Get(d), "d")
+
+	assert.False(t, "Contains(-1)", h.Contains(-1))
+}
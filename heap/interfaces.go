@@ -6,101 +6,92 @@ type Interfaces interface {
 	Len() int
 	// Push inserts an element to the heap.
 	Push(x interface{})
-	// Pop removes the top element from the heap and returns it.
+	// Pop removes the top element from the heap and returns it. It panics if
+	// the heap is empty.
 	Pop() interface{}
+	// TryPop removes the top element from the heap and returns it and true,
+	// or false if the heap is empty.
+	TryPop() (interface{}, bool)
 	// PopAll pops and returns all elements of the heap in reverse order.
 	PopAll() []interface{}
 	// Peek returns the top most element. It panics if the heap is empty.
 	Peek() interface{}
+	// TryPeek returns the top most element and true, or false if the heap is
+	// empty.
+	TryPeek() (interface{}, bool)
+	// At returns the element at index i of the underlying array.
+	At(i int) interface{}
+	// Set replaces the element at index i with x and re-establishes the heap
+	// ordering.
+	Set(i int, x interface{})
+	// Fix re-establishes the heap ordering after the element at index i has
+	// changed.
+	Fix(i int)
+	// Remove removes the element at index i from the heap and returns it.
+	Remove(i int) interface{}
 	// TopNPush inserts an element to the heap if the heap does not reach its
 	// capacity. Otherwise, if the top element is less then the new element
 	// the top element is removed and the new one is inserted.
 	// This method is used to generate a top N largest elements where N is
-	// the capacity of the heap.
+	// the initial capacity of the heap.
 	TopNPush(x interface{})
 	// TopNPopAll is similar to PopAll but keep the capacity of the heap
 	// unchanged.
 	TopNPopAll() []interface{}
+	// Each calls f on each element of the heap in the order of the underlying
+	// array, until f returns false.
+	Each(f func(x interface{}) bool)
+	// Values returns a copy of the underlying array of the heap.
+	Values() []interface{}
+	// Sorted returns all elements of the heap in the order they would be
+	// popped, leaving the heap unchanged.
+	Sorted() []interface{}
+	// PushN inserts elements to the heap.
+	PushN(xs ...interface{})
+	// Meld moves all elements of other into the heap, leaving other empty.
+	// Both heaps must have the same order.
+	Meld(other Interfaces)
+	// Clone returns a copy of the heap with the same order, capacity, checked
+	// mode and shrink policy.
+	Clone() Interfaces
+	// Reset removes all elements from the heap while keeping the underlying
+	// array for reuse.
+	Reset()
+	// Grow grows the capacity of the heap, if necessary, to guarantee space
+	// for another n elements.
+	Grow(n int)
+	// Shrink releases the unused capacity of the underlying array.
+	Shrink()
+	// SetShrinkPolicy sets the ShrinkPolicy applied after an element is
+	// removed. nil means never shrinking.
+	SetShrinkPolicy(p ShrinkPolicy)
+	// SetChecked turns on or off the checked mode, in which the heap property
+	// is verified after every mutation. A violation panics.
+	SetChecked(on bool)
+	// SetStats sets the StatsCollector counting the operations on the heap.
+	// nil disables collecting.
+	SetStats(c *StatsCollector)
 }
 
 type interfaces struct {
-	less func(i, j int) bool
-	list []interface{}
+	Heap[interface{}]
 }
 
-// Interfaces.Len
-func (h *interfaces) Len() int { return len(h.list) }
-
-// Interfaces.Push
-func (h *interfaces) Push(x interface{}) {
-	h.list = append(h.list, x)
-
-	PushLastF(len(h.list), h.less, func(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] })
-}
-
-// Interfaces.TopNPush
-func (h *interfaces) TopNPush(x interface{}) {
-	if len(h.list) < cap(h.list)-1 {
-		h.Push(x)
-		return
-	}
-	h.list = append(h.list, x)
-	if h.less(0, cap(h.list)-1) {
-		h.list[0], h.list[cap(h.list)-1] = x, nil
-		FixF(cap(h.list)-1, h.less, func(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] }, 0)
-	}
-	h.list = h.list[:cap(h.list)-1]
-}
-
-// Interfaces.Pop
-func (h *interfaces) Pop() interface{} {
-	PopToLastF(len(h.list), h.less, func(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] })
-
-	res := h.list[len(h.list)-1]
-	h.list[len(h.list)-1] = nil // remove the reference in h.list
-	h.list = h.list[:len(h.list)-1]
-
-	return res
-}
-
-// Interfaces.PopAll
-func (h *interfaces) PopAll() []interface{} {
-	for n := h.Len(); n > 1; n-- {
-		PopToLastF(n, h.less, func(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] })
-	}
-	res := h.list
-	h.list = nil
-	return res
-}
-
-// Interfaces.TopNPopAll
-func (h *interfaces) TopNPopAll() []interface{} {
-	for n := h.Len(); n > 1; n-- {
-		PopToLastF(n, h.less, func(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] })
-	}
-	res := append([]interface{}(nil), h.list...)
-	for i := range h.list {
-		h.list[i] = nil // remove the reference in h.list
-	}
-	h.list = h.list[:0]
-	return res
+// NewInterfaces returns an instance of Interfaces with a customized less func and the initial capacity.
+func NewInterfaces(less func(x, y interface{}) bool, cap int) Interfaces {
+	return &interfaces{Heap: *NewFunc(less, cap)}
 }
 
-// Interfaces.Peek
-func (h *interfaces) Peek() interface{} {
-	return h.list[0]
+// Interfaces.Clone
+func (h *interfaces) Clone() Interfaces {
+	return &interfaces{Heap: *h.Heap.Clone()}
 }
 
-// NewInterfaces returns an instance of Interfaces with a customized less func and the initial capacity.
-func NewInterfaces(less func(x, y interface{}) bool, cap int) Interfaces {
-	h := &interfaces{}
-
-	h.less = func(i, j int) bool {
-		return less(h.list[i], h.list[j])
-	}
-	if cap > 0 {
-		h.list = make([]interface{}, 0, cap+1)
+// Interfaces.Meld
+func (h *interfaces) Meld(other Interfaces) {
+	if o, ok := other.(*interfaces); ok {
+		h.Heap.Meld(&o.Heap)
+		return
 	}
-
-	return h
+	h.PushN(other.PopAll()...)
 }
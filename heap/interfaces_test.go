@@ -0,0 +1,115 @@
+package heap
+
+import (
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func intLess(x, y interface{}) bool {
+	return x.(int) < y.(int)
+}
+
+func TestInterfaces(t *testing.T) {
+	h := NewInterfaces(intLess, 3)
+
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "peek", h.Peek(), 1)
+
+	res := []interface{}{h.Pop(), h.Pop(), h.Pop(), h.Pop()}
+	assert.Equal(t, "res", res, []interface{}{1, 2, 3, 5})
+
+	h.PushN(5, 2, 1, 3)
+	assert.Equal(t, "PopAll", h.PopAll(), []interface{}{5, 3, 2, 1})
+}
+
+func TestInterfaces_Meld(t *testing.T) {
+	h1, h2 := NewInterfaces(intLess, 0), NewInterfaces(intLess, 0)
+	h1.PushN(3, 1)
+	h2.PushN(4, 2)
+	h1.Meld(h2)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+
+	h3 := NewPairingFunc(intLess)
+	h3.Push(0)
+	h1.Meld(pairingInterfaces{p: h3})
+	assert.Equal(t, "h3.Len()", h3.Len(), 0)
+	assert.Equal(t, "PopAll", h1.PopAll(), []interface{}{4, 3, 2, 1, 0})
+}
+
+// pairingInterfaces adapts a Pairing heap to Interfaces. Methods not
+// implemented here panic.
+type pairingInterfaces struct {
+	Interfaces
+	p *Pairing[interface{}]
+}
+
+func (h pairingInterfaces) Len() int              { return h.p.Len() }
+func (h pairingInterfaces) PopAll() []interface{} { return h.p.PopAll() }
+
+func TestInterfaces_FixRemoveSet(t *testing.T) {
+	h := NewInterfaces(intLess, 0)
+	h.PushN(5, 2, 1, 3)
+
+	h.Set(0, 6)
+	assert.Equal(t, "Peek", h.Peek(), 2)
+	assert.Equal(t, "Remove", h.Remove(0), 2)
+	assert.Equal(t, "At(0)", h.At(0), 3)
+	h.Fix(0)
+	assert.Equal(t, "PopAll", h.PopAll(), []interface{}{6, 5, 3})
+}
+
+func TestInterfaces_Empty(t *testing.T) {
+	h := NewInterfaces(intLess, 0)
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Peek", recovered(func() { h.Peek() }), "heap: empty")
+
+	h.Push(1)
+	v, ok := h.TryPeek()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+	v, ok = h.TryPop()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+	_, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+}
+
+func TestInterfaces_Iterate(t *testing.T) {
+	h := NewInterfaces(intLess, 0)
+	h.PushN(5, 2, 1, 3)
+
+	var each []interface{}
+	h.Each(func(x interface{}) bool {
+		each = append(each, x)
+		return true
+	})
+	assert.Equal(t, "each", each, []interface{}{1, 2, 5, 3})
+	assert.Equal(t, "Values", h.Values(), each)
+	assert.Equal(t, "Sorted", h.Sorted(), []interface{}{1, 2, 3, 5})
+}
+
+func TestInterfaces_Capacity(t *testing.T) {
+	h := NewInterfaces(intLess, 2)
+	h.PushN(3, 1)
+	c := h.Clone()
+	h.Reset()
+	assert.Equal(t, "len", h.Len(), 0)
+	assert.Equal(t, "c.Len()", c.Len(), 2)
+
+	c.SetShrinkPolicy(func(len, cap int) int {
+		return len
+	})
+	c.Grow(5)
+	c.Pop()
+	c.Shrink()
+	assert.Equal(t, "PopAll", c.PopAll(), []interface{}{3})
+}
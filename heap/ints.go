@@ -1,79 +1,32 @@
 package heap
 
-import (
-	"sort"
-)
-
 // A heap for ints. The pointer to the zero value of Ints is a heap with default less
 // func which compares int values on the natual order.
 // Use NewInts to customize less func and initial capacity.
 type Ints struct {
-	// Less func given indexes of i, j in the list slice.
-	less func(i, j int) bool
-	list sort.IntSlice
-}
-
-// Len returns the number of elements in the current heap.
-func (h *Ints) Len() int {
-	return len(h.list)
+	Heap[int]
 }
 
-// Push inserts an element to the heap.
-func (h *Ints) Push(x int) {
-	h.list = append(h.list, x)
-
-	if h.less == nil {
-		PushLastF(len(h.list), h.list.Less, h.list.Swap)
-	} else {
-		PushLastF(len(h.list), h.less, h.list.Swap)
-	}
-}
-
-// Peek returns the top most element. It panics if the heap is empty.
-func (h *Ints) Peek() int {
-	return h.list[0]
+// NewInts returns a *Ints with customized less func and initial capacity.
+// The initial capacity is also the N of TopNPush.
+func NewInts(less func(x, y int) bool, cap int) *Ints {
+	return &Ints{Heap: *NewFunc(less, cap)}
 }
 
-// Pop removes the top element from the heap and returns it.
-func (h *Ints) Pop() int {
-	if h.less == nil {
-		PopToLastF(len(h.list), h.list.Less, h.list.Swap)
-	} else {
-		PopToLastF(len(h.list), h.less, h.list.Swap)
-	}
-
-	res := h.list[len(h.list)-1]
-	h.list = h.list[:len(h.list)-1]
-
-	return res
+// Clone returns a copy of the heap with the same order, capacity, checked mode
+// and shrink policy.
+func (h *Ints) Clone() *Ints {
+	return &Ints{Heap: *h.Heap.Clone()}
 }
 
-// PopAll pops and returns all elements of the heap in reverse order.
-func (h *Ints) PopAll() []int {
-	for n := h.Len(); n > 1; n-- {
-		if h.less == nil {
-			PopToLastF(n, h.list.Less, h.list.Swap)
-		} else {
-			PopToLastF(n, h.less, h.list.Swap)
-		}
-	}
-	res := h.list
-	h.list = nil
-	return res
+// Meld moves all elements of other into h, leaving other empty. Both heaps
+// must have the same order.
+func (h *Ints) Meld(other *Ints) {
+	h.Heap.Meld(&other.Heap)
 }
 
-// NewInts returns a *Ints with customized less func and initial capacity.
-func NewInts(less func(x, y int) bool, cap int) *Ints {
-	h := &Ints{}
-
-	if less != nil {
-		h.less = func(i, j int) bool {
-			return less(h.list[i], h.list[j])
-		}
-	}
-	if cap > 0 {
-		h.list = make(sort.IntSlice, 0, cap)
-	}
-
-	return h
+// TopNInts returns the n largest elements of values, according to less, in
+// descending order. If less is nil, the natural order is used.
+func TopNInts(values []int, n int, less func(x, y int) bool) []int {
+	return TopN(values, n, less)
 }
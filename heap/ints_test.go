@@ -131,3 +131,96 @@ func BenchmarkPlusIntHeap_Less(b *testing.B) {
 		}
 	}
 }
+
+func TestInts_Meld(t *testing.T) {
+	var h1, h2 Ints
+	h1.Push(3)
+	h1.Push(1)
+	h2.Push(2)
+	h1.Meld(&h2)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+	assert.Equal(t, "PopAll", h1.PopAll(), []int{3, 2, 1})
+}
+
+func TestInts_Checked(t *testing.T) {
+	data := []int{5, 2, 1, 3}
+	h := NewInts(func(i, j int) bool {
+		return data[i] < data[j]
+	}, 0)
+	h.SetChecked(true)
+	h.Push(0)
+	h.Push(1)
+	h.Push(2)
+
+	data[1] = 0
+	assert.Panic(t, "Push", func() {
+		h.Push(3)
+	})
+}
+
+func TestInts_TopN(t *testing.T) {
+	h := NewInts(nil, 3)
+	for _, v := range []int{5, 1, 9, 3, 7, 2} {
+		h.TopNPush(v)
+	}
+	assert.Equal(t, "len", h.Len(), 3)
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []int{9, 7, 5})
+	h.TopNPush(4)
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []int{4})
+
+	assert.Equal(t, "TopNInts", TopNInts([]int{5, 1, 9, 3, 7, 2}, 2, nil), []int{9, 7})
+	assert.Equal(t, "TopNInts", TopNInts([]int{5, 1, 9, 3, 7, 2}, 2, func(x, y int) bool {
+		return x > y
+	}), []int{1, 2})
+	assert.Equal(t, "TopNInts", TopNInts([]int{5, 1}, 3, nil), []int{5, 1})
+	assert.Equal(t, "TopNInts", TopNInts([]int{5, 1}, 0, nil), []int(nil))
+}
+
+func TestInts_FixRemoveSet(t *testing.T) {
+	var h Ints
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+
+	h.Set(0, 6)
+	assert.Equal(t, "Peek", h.Peek(), 2)
+	assert.Equal(t, "Remove", h.Remove(0), 2)
+	assert.Equal(t, "Peek", h.Peek(), 3)
+
+	data := []int{5, 2, 1, 3}
+	hl := NewInts(func(i, j int) bool {
+		return data[i] < data[j]
+	}, 0)
+	hl.Push(0)
+	hl.Push(1)
+	hl.Push(2)
+	hl.Push(3)
+	assert.Equal(t, "Peek", hl.Peek(), 2)
+	data[2] = 9
+	hl.Fix(0)
+	assert.Equal(t, "Peek", hl.Peek(), 1)
+	assert.Equal(t, "At(0)", hl.At(0), 1)
+}
+
+func TestInts_Empty(t *testing.T) {
+	var h Ints
+	_, ok := h.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), "heap: empty")
+
+	h.Push(1)
+	v, ok := h.TryPop()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+}
+
+func TestInts_Clone(t *testing.T) {
+	var h Ints
+	h.Push(5)
+	h.Push(2)
+	c := h.Clone()
+	c.Push(1)
+	assert.Equal(t, "h.Peek()", h.Peek(), 2)
+	assert.Equal(t, "c.PopAll()", c.PopAll(), []int{5, 2, 1})
+}
@@ -0,0 +1,183 @@
+package heap
+
+import (
+	"cmp"
+)
+
+// Lazy is a heap supporting deleting elements by value with tombstones. Delete
+// records a tombstone for the key of a value in O(1), and Pop and Peek skip the
+// deleted elements transparently. When the tombstones exceed a ratio of the
+// underlying heap, the heap is compacted.
+//
+// Elements are identified by the keys extracted from them, so elements of the
+// same key are interchangeable for Delete. Use NewLazy, NewLazyInts or
+// NewLazyStrings to create an instance.
+type Lazy[T any, K comparable] struct {
+	h   Heap[T]
+	key func(x T) K
+	// The number of live elements of each key.
+	live map[K]int
+	// The number of tombstones of each key, and in total.
+	tombs  map[K]int
+	ntombs int
+	// Compact when ntombs > ratio * h.Len().
+	ratio float64
+}
+
+// DefaultCompactRatio is the default ratio of tombstones to all elements in the
+// underlying heap above which a Lazy heap is compacted.
+const DefaultCompactRatio = 0.5
+
+// NewLazy returns a *Lazy with a customized less func, a func extracting the
+// keys of elements and the initial capacity. If less is nil, the natural order
+// of T is used.
+func NewLazy[T any, K comparable](less func(x, y T) bool, key func(x T) K, cap int) *Lazy[T, K] {
+	return &Lazy[T, K]{
+		h:     *NewFunc(less, cap),
+		key:   key,
+		live:  make(map[K]int),
+		tombs: make(map[K]int),
+		ratio: DefaultCompactRatio,
+	}
+}
+
+// NewLazyInts returns a *Lazy for ints with a customized less func and the
+// initial capacity. If less is nil, the natural order is used.
+func NewLazyInts(less func(x, y int) bool, cap int) *Lazy[int, int] {
+	return NewLazy(less, identity[int], cap)
+}
+
+// NewLazyStrings returns a *Lazy for strings with a customized less func and
+// the initial capacity. If less is nil, the natural order is used.
+func NewLazyStrings(less func(x, y string) bool, cap int) *Lazy[string, string] {
+	return NewLazy(less, identity[string], cap)
+}
+
+func identity[T cmp.Ordered](x T) T {
+	return x
+}
+
+// SetCompactRatio sets the ratio of tombstones to all elements in the
+// underlying heap above which the heap is compacted. The default is
+// DefaultCompactRatio.
+func (h *Lazy[T, K]) SetCompactRatio(ratio float64) {
+	h.ratio = ratio
+	h.maybeCompact()
+}
+
+// Len returns the number of elements, excluding deleted ones, in the current
+// heap.
+func (h *Lazy[T, K]) Len() int {
+	return h.h.Len() - h.ntombs
+}
+
+// Push inserts an element to the heap.
+func (h *Lazy[T, K]) Push(x T) {
+	h.h.Push(x)
+	h.live[h.key(x)]++
+}
+
+// Delete deletes an element with the same key as x and returns true, or returns
+// false if there is no such element.
+// The complexity is O(1) amortized.
+func (h *Lazy[T, K]) Delete(x T) bool {
+	k := h.key(x)
+	if h.live[k] == 0 {
+		return false
+	}
+	h.decLive(k)
+	h.tombs[k]++
+	h.ntombs++
+	h.maybeCompact()
+	return true
+}
+
+// Peek returns the top most element. It panics if the heap is empty.
+func (h *Lazy[T, K]) Peek() T {
+	h.skip()
+	return h.h.Peek()
+}
+
+// TryPeek returns the top most element and true, or false if the heap is empty.
+func (h *Lazy[T, K]) TryPeek() (T, bool) {
+	h.skip()
+	return h.h.TryPeek()
+}
+
+// Pop removes the top element from the heap and returns it. It panics if the
+// heap is empty.
+func (h *Lazy[T, K]) Pop() T {
+	h.skip()
+	x := h.h.Pop()
+	h.decLive(h.key(x))
+	// Popping changes the ratio of tombstones as well.
+	h.skip()
+	h.maybeCompact()
+	return x
+}
+
+// TryPop removes the top element from the heap and returns it and true, or
+// false if the heap is empty.
+func (h *Lazy[T, K]) TryPop() (T, bool) {
+	if h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Pop(), true
+}
+
+// PopAll pops and returns all elements of the heap in reverse order.
+func (h *Lazy[T, K]) PopAll() []T {
+	h.Compact()
+	clear(h.live)
+	return h.h.PopAll()
+}
+
+// Compact removes all deleted elements from the underlying heap.
+// The complexity is O(N), where N is the size of the underlying heap.
+func (h *Lazy[T, K]) Compact() {
+	if h.ntombs == 0 {
+		return
+	}
+	list := h.h.list[:0]
+	for _, x := range h.h.list {
+		if k := h.key(x); h.tombs[k] > 0 {
+			h.decTombs(k)
+			continue
+		}
+		list = append(list, x)
+	}
+	clear(h.h.list[len(list):]) // remove the references in the tail
+	h.h.init(list)
+}
+
+// skip pops the deleted elements at the top.
+func (h *Lazy[T, K]) skip() {
+	for h.ntombs > 0 && h.h.Len() > 0 {
+		k := h.key(h.h.Peek())
+		if h.tombs[k] == 0 {
+			return
+		}
+		h.h.Pop()
+		h.decTombs(k)
+	}
+}
+
+func (h *Lazy[T, K]) maybeCompact() {
+	if float64(h.ntombs) > h.ratio*float64(h.h.Len()) {
+		h.Compact()
+	}
+}
+
+func (h *Lazy[T, K]) decLive(k K) {
+	if h.live[k]--; h.live[k] == 0 {
+		delete(h.live, k)
+	}
+}
+
+func (h *Lazy[T, K]) decTombs(k K) {
+	h.ntombs--
+	if h.tombs[k]--; h.tombs[k] == 0 {
+		delete(h.tombs, k)
+	}
+}
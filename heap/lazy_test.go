@@ -0,0 +1,129 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestLazy_Ints(t *testing.T) {
+	h := NewLazyInts(nil, 0)
+	for _, x := range []int{5, 2, 1, 3, 4, 2} {
+		h.Push(x)
+	}
+	assert.Equal(t, "len", h.Len(), 6)
+
+	assert.True(t, "Delete(1)", h.Delete(1))
+	assert.False(t, "Delete(1)", h.Delete(1))
+	assert.False(t, "Delete(10)", h.Delete(10))
+	assert.True(t, "Delete(2)", h.Delete(2))
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "peek", h.Peek(), 2)
+
+	res := []int{h.Pop(), h.Pop(), h.Pop(), h.Pop()}
+	assert.Equal(t, "res", res, []int{2, 3, 4, 5})
+	assert.Equal(t, "len", h.Len(), 0)
+
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), errEmpty)
+}
+
+func TestLazy_Strings(t *testing.T) {
+	h := NewLazyStrings(func(x, y string) bool { return x > y }, 0)
+	for _, x := range []string{"b", "d", "a", "c"} {
+		h.Push(x)
+	}
+	assert.True(t, "Delete(d)", h.Delete("d"))
+	assert.Equal(t, "PopAll", h.PopAll(), []string{"a", "b", "c"})
+	assert.Equal(t, "len", h.Len(), 0)
+
+	// Deleting a popped value fails.
+	h.Push("x")
+	assert.Equal(t, "Pop", h.Pop(), "x")
+	assert.False(t, "Delete(x)", h.Delete("x"))
+}
+
+func TestLazy_Key(t *testing.T) {
+	type job struct {
+		id       string
+		priority int
+	}
+	h := NewLazy(func(x, y job) bool {
+		return x.priority < y.priority
+	}, func(x job) string {
+		return x.id
+	}, 0)
+	h.Push(job{"a", 3})
+	h.Push(job{"b", 1})
+	h.Push(job{"c", 2})
+
+	// Only the key matters for Delete.
+	assert.True(t, "Delete(b)", h.Delete(job{id: "b"}))
+	assert.Equal(t, "Pop", h.Pop(), job{"c", 2})
+	assert.Equal(t, "Pop", h.Pop(), job{"a", 3})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestLazy_Compact(t *testing.T) {
+	h := NewLazyInts(nil, 0)
+	for i := 0; i < 10; i++ {
+		h.Push(i)
+	}
+	for i := 5; i < 10; i++ {
+		h.Delete(i)
+	}
+	// Exactly half, not compacted yet.
+	assert.Equal(t, "ntombs", h.ntombs, 5)
+	assert.Equal(t, "underlying len", h.h.Len(), 10)
+
+	h.Delete(4)
+	assert.Equal(t, "ntombs", h.ntombs, 0)
+	assert.Equal(t, "underlying len", h.h.Len(), 4)
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "Sorted", h.h.Sorted(), []int{0, 1, 2, 3})
+
+	h.SetCompactRatio(0)
+	h.Delete(3)
+	assert.Equal(t, "underlying len", h.h.Len(), 3)
+
+	h.SetCompactRatio(1)
+	h.Delete(0)
+	assert.Equal(t, "underlying len", h.h.Len(), 3)
+	h.Compact()
+	assert.Equal(t, "underlying len", h.h.Len(), 2)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{2, 1})
+}
+
+func TestLazy_Random(t *testing.T) {
+	h := NewLazyInts(nil, 0)
+	var live []int
+	for i := 0; i < 10000; i++ {
+		switch x := rand.Intn(100); {
+		case len(live) == 0 || rand.Intn(3) == 0:
+			h.Push(x)
+			live = append(live, x)
+		case rand.Intn(2) == 0:
+			j := rand.Intn(len(live))
+			assert.True(t, "Delete", h.Delete(live[j]))
+			live = append(live[:j], live[j+1:]...)
+		default:
+			sort.Ints(live)
+			assert.Equal(t, "Pop", h.Pop(), live[0])
+			live = live[1:]
+		}
+		assert.Equal(t, "len", h.Len(), len(live))
+	}
+	sort.Ints(live)
+	var res []int
+	for h.Len() > 0 {
+		res = append(res, h.Pop())
+	}
+	assert.Equal(t, "res", res, live)
+	assert.Equal(t, "underlying len", h.h.Len(), 0)
+	assert.Equal(t, "tombs", len(h.tombs), 0)
+}
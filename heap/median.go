@@ -0,0 +1,245 @@
+package heap
+
+import (
+	"cmp"
+)
+
+// RunningMedian tracks the median of a stream of float64 values with two heaps:
+// a max-heap of the lower half and a min-heap of the upper half. Add is
+// O(log(N)), Median is O(1) and Remove is O(N).
+//
+// The zero value of RunningMedian is an empty tracker ready to use.
+type RunningMedian struct {
+	// lo is a max-heap of the lower half, which has the same number of, or one
+	// more, elements than hi, the min-heap of the upper half.
+	lo, hi Float64s
+}
+
+// Len returns the number of values in the tracker.
+func (m *RunningMedian) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+// Add adds a value to the tracker.
+// The complexity is O(log(N)), where N = m.Len().
+func (m *RunningMedian) Add(x float64) {
+	medianAdd(&m.lo.Heap, &m.hi.Heap, x)
+}
+
+// Remove removes a value equal to x from the tracker and returns true, or
+// returns false if there is no such value.
+// The complexity is O(N), where N = m.Len().
+func (m *RunningMedian) Remove(x float64) bool {
+	return medianRemove(&m.lo.Heap, &m.hi.Heap, x)
+}
+
+// Median returns the median of the values, which is the mean of the two middle
+// ones if the number of values is even. It panics if the tracker is empty.
+func (m *RunningMedian) Median() float64 {
+	if m.lo.Len() > m.hi.Len() {
+		return m.lo.Peek()
+	}
+	lo, hi := m.lo.Peek(), m.hi.Peek()
+	return lo + (hi-lo)/2
+}
+
+// TryMedian returns the median of the values and true, or false if the tracker
+// is empty.
+func (m *RunningMedian) TryMedian() (float64, bool) {
+	if m.lo.Len() == 0 {
+		return 0, false
+	}
+	return m.Median(), true
+}
+
+// RunningMedianOf is similar to RunningMedian but for values of any ordered
+// type. Since the values are not necessarily numbers, the two middle ones are
+// returned by Medians if the number of values is even.
+//
+// The zero value of RunningMedianOf[T] is an empty tracker ready to use.
+type RunningMedianOf[T cmp.Ordered] struct {
+	lo, hi Heap[T]
+}
+
+// Len returns the number of values in the tracker.
+func (m *RunningMedianOf[T]) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+// Add adds a value to the tracker.
+// The complexity is O(log(N)), where N = m.Len().
+func (m *RunningMedianOf[T]) Add(x T) {
+	medianAdd(&m.lo, &m.hi, x)
+}
+
+// Remove removes a value equal to x from the tracker and returns true, or
+// returns false if there is no such value.
+// The complexity is O(N), where N = m.Len().
+func (m *RunningMedianOf[T]) Remove(x T) bool {
+	return medianRemove(&m.lo, &m.hi, x)
+}
+
+// Median returns the lower median of the values. It panics if the tracker is
+// empty.
+func (m *RunningMedianOf[T]) Median() T {
+	return m.lo.Peek()
+}
+
+// TryMedian returns the lower median of the values and true, or false if the
+// tracker is empty.
+func (m *RunningMedianOf[T]) TryMedian() (T, bool) {
+	return m.lo.TryPeek()
+}
+
+// Medians returns the lower and the upper median of the values, which are the
+// same if the number of values is odd. It panics if the tracker is empty.
+func (m *RunningMedianOf[T]) Medians() (lower, upper T) {
+	lower = m.lo.Peek()
+	if m.lo.Len() > m.hi.Len() {
+		return lower, lower
+	}
+	return lower, m.hi.Peek()
+}
+
+// WindowedMedian tracks the median of the last values of a stream of float64
+// values. When the window is full, Add evicts the oldest value. Use
+// NewWindowedMedian to create an instance.
+//
+// The halves are Indexed heaps of the slots in the window, so the oldest value
+// is removed through its Handle in O(log(W)) time.
+type WindowedMedian struct {
+	// A ring buffer of the values in the order they were added.
+	slots []windowSlot
+	// The index of the oldest slot if the window is full.
+	next int
+	size int
+	// lo is a max-heap of the slots of the lower half, which has the same
+	// number of, or one more, elements than hi, the min-heap of the upper half.
+	lo, hi *Indexed[int]
+}
+
+type windowSlot struct {
+	value float64
+	// The Handle of the slot in lo if inLo, hi otherwise.
+	handle Handle
+	inLo   bool
+}
+
+// NewWindowedMedian returns a *WindowedMedian with a window of size values. It
+// panics if size is not positive.
+func NewWindowedMedian(size int) *WindowedMedian {
+	if size <= 0 {
+		panic("heap: non-positive window size")
+	}
+	w := &WindowedMedian{slots: make([]windowSlot, 0, size), size: size}
+	w.lo = NewIndexedFunc(func(i, j int) bool {
+		return cmp.Less(w.slots[j].value, w.slots[i].value)
+	}, size/2+1)
+	w.hi = NewIndexedFunc(func(i, j int) bool {
+		return cmp.Less(w.slots[i].value, w.slots[j].value)
+	}, size/2)
+	return w
+}
+
+// Len returns the number of values in the window.
+func (w *WindowedMedian) Len() int {
+	return len(w.slots)
+}
+
+// Add adds a value to the window, evicting the oldest one if the window is
+// full.
+// The complexity is O(log(W)), where W is the size of the window.
+func (w *WindowedMedian) Add(x float64) {
+	slot := len(w.slots)
+	if slot < w.size {
+		w.slots = append(w.slots, windowSlot{})
+	} else {
+		slot = w.next
+		if s := w.slots[slot]; s.inLo {
+			w.lo.Remove(s.handle)
+		} else {
+			w.hi.Remove(s.handle)
+		}
+		if w.next++; w.next == w.size {
+			w.next = 0
+		}
+	}
+	w.slots[slot].value = x
+	w.push(slot, w.lo.Len() == 0 || !cmp.Less(w.slots[w.lo.Peek()].value, x))
+
+	if w.lo.Len() > w.hi.Len()+1 {
+		w.push(w.lo.Pop(), false)
+	} else if w.hi.Len() > w.lo.Len() {
+		w.push(w.hi.Pop(), true)
+	}
+}
+
+// Median returns the median of the values in the window. It panics if the
+// window is empty.
+func (w *WindowedMedian) Median() float64 {
+	lo := w.slots[w.lo.Peek()].value
+	if w.lo.Len() > w.hi.Len() {
+		return lo
+	}
+	hi := w.slots[w.hi.Peek()].value
+	return lo + (hi-lo)/2
+}
+
+// TryMedian returns the median of the values in the window and true, or false
+// if the window is empty.
+func (w *WindowedMedian) TryMedian() (float64, bool) {
+	if len(w.slots) == 0 {
+		return 0, false
+	}
+	return w.Median(), true
+}
+
+// push pushes slot to lo if toLo, hi otherwise.
+func (w *WindowedMedian) push(slot int, toLo bool) {
+	s := &w.slots[slot]
+	if s.inLo = toLo; toLo {
+		s.handle = w.lo.Push(slot)
+	} else {
+		s.handle = w.hi.Push(slot)
+	}
+}
+
+// medianAdd adds x to the halves lo and hi of a running median.
+func medianAdd[T cmp.Ordered](lo, hi *Heap[T], x T) {
+	if lo.less == nil {
+		lo.less = func(x, y T) bool { return cmp.Less(y, x) }
+	}
+	if lo.Len() == 0 || !cmp.Less(lo.Peek(), x) {
+		lo.Push(x)
+	} else {
+		hi.Push(x)
+	}
+	medianBalance(lo, hi)
+}
+
+// medianRemove removes a value equal to x from the halves lo and hi of a
+// running median.
+func medianRemove[T cmp.Ordered](lo, hi *Heap[T], x T) bool {
+	h := hi
+	if lo.Len() > 0 && !cmp.Less(lo.Peek(), x) {
+		h = lo
+	}
+	for i, v := range h.list {
+		if cmp.Compare(v, x) == 0 {
+			h.Remove(i)
+			medianBalance(lo, hi)
+			return true
+		}
+	}
+	return false
+}
+
+// medianBalance moves the top of one half to the other to keep lo having the
+// same number of, or one more, elements than hi.
+func medianBalance[T cmp.Ordered](lo, hi *Heap[T]) {
+	if lo.Len() > hi.Len()+1 {
+		hi.Push(lo.Pop())
+	} else if hi.Len() > lo.Len() {
+		lo.Push(hi.Pop())
+	}
+}
@@ -0,0 +1,119 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestRunningMedian(t *testing.T) {
+	var m RunningMedian
+	assert.Equal(t, "len", m.Len(), 0)
+	_, ok := m.TryMedian()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Median", recovered(func() { m.Median() }), errEmpty)
+
+	m.Add(5)
+	assert.Equal(t, "median", m.Median(), 5.)
+	m.Add(1)
+	assert.Equal(t, "median", m.Median(), 3.)
+	m.Add(2)
+	assert.Equal(t, "median", m.Median(), 2.)
+	m.Add(10)
+	assert.Equal(t, "median", m.Median(), 3.5)
+	assert.Equal(t, "len", m.Len(), 4)
+
+	assert.True(t, "Remove(2)", m.Remove(2))
+	assert.False(t, "Remove(2)", m.Remove(2))
+	assert.Equal(t, "median", m.Median(), 5.)
+	assert.True(t, "Remove(10)", m.Remove(10))
+	median, ok := m.TryMedian()
+	assert.True(t, "ok", ok)
+	assert.Equal(t, "median", median, 3.)
+}
+
+func TestRunningMedianOf(t *testing.T) {
+	var m RunningMedianOf[string]
+	_, ok := m.TryMedian()
+	assert.False(t, "ok", ok)
+
+	for _, s := range []string{"d", "a", "c", "b"} {
+		m.Add(s)
+	}
+	assert.Equal(t, "median", m.Median(), "b")
+	lower, upper := m.Medians()
+	assert.Equal(t, "lower", lower, "b")
+	assert.Equal(t, "upper", upper, "c")
+
+	assert.True(t, "Remove(a)", m.Remove("a"))
+	lower, upper = m.Medians()
+	assert.Equal(t, "lower", lower, "c")
+	assert.Equal(t, "upper", upper, "c")
+	assert.Equal(t, "len", m.Len(), 3)
+}
+
+func TestRunningMedian_Random(t *testing.T) {
+	var m RunningMedianOf[int]
+	var values []int
+	for i := 0; i < 2000; i++ {
+		if len(values) > 0 && rand.Intn(3) == 0 {
+			j := rand.Intn(len(values))
+			assert.True(t, "Remove", m.Remove(values[j]))
+			values = append(values[:j], values[j+1:]...)
+		} else {
+			x := rand.Intn(100)
+			m.Add(x)
+			values = append(values, x)
+		}
+		assert.Equal(t, "len", m.Len(), len(values))
+		if len(values) == 0 {
+			continue
+		}
+		sorted := append([]int(nil), values...)
+		sort.Ints(sorted)
+		lower, upper := m.Medians()
+		assert.Equal(t, "lower", lower, sorted[(len(sorted)-1)/2])
+		assert.Equal(t, "upper", upper, sorted[len(sorted)/2])
+	}
+}
+
+func TestWindowedMedian(t *testing.T) {
+	w := NewWindowedMedian(3)
+	_, ok := w.TryMedian()
+	assert.False(t, "ok", ok)
+
+	var medians []float64
+	for _, x := range []float64{1, 9, 2, 8, 3, 7, 7} {
+		w.Add(x)
+		medians = append(medians, w.Median())
+	}
+	assert.Equal(t, "medians", medians, []float64{1, 5, 2, 8, 3, 7, 7})
+	assert.Equal(t, "len", w.Len(), 3)
+
+	assert.Equal(t, "NewWindowedMedian", recovered(func() {
+		NewWindowedMedian(0)
+	}), "heap: non-positive window size")
+}
+
+func TestWindowedMedian_Random(t *testing.T) {
+	for _, size := range []int{1, 2, 5, 16} {
+		w := NewWindowedMedian(size)
+		var values []float64
+		for i := 0; i < 1000; i++ {
+			x := float64(rand.Intn(50))
+			w.Add(x)
+			if values = append(values, x); len(values) > size {
+				values = values[1:]
+			}
+			assert.Equal(t, "len", w.Len(), len(values))
+
+			sorted := append([]float64(nil), values...)
+			sort.Float64s(sorted)
+			n := len(sorted)
+			exp := (sorted[(n-1)/2] + sorted[n/2]) / 2
+			assert.Equal(t, "median", w.Median(), exp)
+		}
+	}
+}
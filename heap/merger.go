@@ -0,0 +1,119 @@
+package heap
+
+// Merger merges sorted sources into a single sorted stream. Each source is a
+// func returning the next value and true, or false when it is exhausted.
+// Use NewMerger to create an instance.
+//
+// A heap of the heads of the sources is maintained, so advancing a source is a
+// single sift-down. Equal values from different sources are yielded in the
+// order of the sources.
+type Merger[T any] struct {
+	less    func(x, y T) bool
+	sources []func() (T, bool)
+	dedup   bool
+
+	started bool
+	heads   []mergerHead[T]
+	// The last value returned by Next, for dedup.
+	last    T
+	hasLast bool
+
+	lessIdx func(i, j int) bool
+	swapIdx func(i, j int)
+}
+
+type mergerHead[T any] struct {
+	value T
+	// The index of the source.
+	src int
+}
+
+// NewMerger returns a *Merger merging sources, each of which yields values in
+// ascending order according to less.
+func NewMerger[T any](less func(x, y T) bool, sources ...func() (T, bool)) *Merger[T] {
+	m := &Merger[T]{
+		less:    less,
+		sources: sources,
+	}
+	m.lessIdx = func(i, j int) bool {
+		hi, hj := m.heads[i], m.heads[j]
+		if m.less(hi.value, hj.value) {
+			return true
+		}
+		if m.less(hj.value, hi.value) {
+			return false
+		}
+		return hi.src < hj.src
+	}
+	m.swapIdx = func(i, j int) {
+		m.heads[i], m.heads[j] = m.heads[j], m.heads[i]
+	}
+	return m
+}
+
+// SetDedup turns on or off de-duplication. When on, a value equal to the
+// previously returned one is skipped, so only the first of equal values is
+// returned.
+func (m *Merger[T]) SetDedup(on bool) {
+	m.dedup = on
+}
+
+// Next returns the next value in the merged stream and true, or false if all
+// sources are exhausted.
+func (m *Merger[T]) Next() (T, bool) {
+	if !m.started {
+		m.start()
+	}
+	for len(m.heads) > 0 {
+		res := m.heads[0].value
+		m.advance()
+		if m.dedup {
+			if m.hasLast && !m.less(m.last, res) {
+				continue
+			}
+			m.last, m.hasLast = res, true
+		}
+		return res, true
+	}
+	var zero T
+	return zero, false
+}
+
+// start fetches the first values of all sources.
+func (m *Merger[T]) start() {
+	m.started = true
+	m.heads = make([]mergerHead[T], 0, len(m.sources))
+	for i, src := range m.sources {
+		if v, ok := src(); ok {
+			m.heads = append(m.heads, mergerHead[T]{value: v, src: i})
+			PushLastF(len(m.heads), m.lessIdx, m.swapIdx)
+		}
+	}
+}
+
+// advance replaces the top head with the next value of its source, or removes
+// it if the source is exhausted.
+func (m *Merger[T]) advance() {
+	if v, ok := m.sources[m.heads[0].src](); ok {
+		m.heads[0].value = v
+		FixF(len(m.heads), m.lessIdx, m.swapIdx, 0)
+		return
+	}
+	PopToLastF(len(m.heads), m.lessIdx, m.swapIdx)
+	n := len(m.heads) - 1
+	m.heads[n] = mergerHead[T]{} // remove the reference in m.heads
+	m.heads = m.heads[:n]
+}
+
+// SliceSource returns a source for Merger yielding elements of s in order.
+func SliceSource[T any](s []T) func() (T, bool) {
+	return func() (T, bool) {
+		if len(s) == 0 {
+			var zero T
+			return zero, false
+		}
+		v := s[0]
+		s = s[1:]
+		return v, true
+	}
+}
@@ -0,0 +1,78 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func mergeAll[T any](m *Merger[T]) []T {
+	var res []T
+	for v, ok := m.Next(); ok; v, ok = m.Next() {
+		res = append(res, v)
+	}
+	return res
+}
+
+func TestMerger(t *testing.T) {
+	m := NewMerger(func(x, y int) bool {
+		return x < y
+	}, SliceSource([]int{1, 4, 7}), SliceSource([]int(nil)), SliceSource([]int{2, 4, 8, 9}), SliceSource([]int{3}))
+	assert.Equal(t, "res", mergeAll(m), []int{1, 2, 3, 4, 4, 7, 8, 9})
+	_, ok := m.Next()
+	assert.False(t, "ok", ok)
+
+	m = NewMerger[int](func(x, y int) bool {
+		return x < y
+	})
+	assert.Equal(t, "res", mergeAll(m), []int(nil))
+}
+
+func TestMerger_Dedup(t *testing.T) {
+	m := NewMerger(func(x, y int) bool {
+		return x < y
+	}, SliceSource([]int{1, 1, 4, 7}), SliceSource([]int{1, 4, 4, 8}))
+	m.SetDedup(true)
+	assert.Equal(t, "res", mergeAll(m), []int{1, 4, 7, 8})
+}
+
+func TestMerger_Stable(t *testing.T) {
+	type entry struct {
+		key, src int
+	}
+	less := func(x, y entry) bool {
+		return x.key < y.key
+	}
+	m := NewMerger(less,
+		SliceSource([]entry{{1, 0}, {2, 0}}),
+		SliceSource([]entry{{1, 1}, {2, 1}}),
+		SliceSource([]entry{{1, 2}}))
+	assert.Equal(t, "res", mergeAll(m), []entry{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 1}})
+
+	m = NewMerger(less,
+		SliceSource([]entry{{1, 0}, {2, 0}}),
+		SliceSource([]entry{{1, 1}, {2, 1}}))
+	m.SetDedup(true)
+	assert.Equal(t, "res", mergeAll(m), []entry{{1, 0}, {2, 0}})
+}
+
+func TestMerger_Random(t *testing.T) {
+	var all []int
+	var sources []func() (int, bool)
+	for i := 0; i < 20; i++ {
+		s := make([]int, rand.Intn(50))
+		for j := range s {
+			s[j] = rand.Intn(100)
+		}
+		sort.Ints(s)
+		all = append(all, s...)
+		sources = append(sources, SliceSource(s))
+	}
+	sort.Ints(all)
+	m := NewMerger(func(x, y int) bool {
+		return x < y
+	}, sources...)
+	assert.Equal(t, "res", mergeAll(m), all)
+}
@@ -0,0 +1,399 @@
+package heap
+
+import (
+	"cmp"
+	"math/bits"
+	"sort"
+)
+
+// MinMaxInit heapifies an array defined by the sort.Interface into a min-max
+// heap, a double-ended heap where both the minimum and the maximum element can
+// be popped.
+// The complexity is O(N), where N = h.Len().
+//
+// Like the binary heap functions, the last element is used as the in/out
+// place: MinMaxPushLast, PopMinToLast and PopMaxToLast.
+func MinMaxInit(h sort.Interface) {
+	n := h.Len()
+	for i := n/2 - 1; i >= 0; i-- {
+		minMaxDown(n, h.Less, h.Swap, i)
+	}
+}
+
+// Similar to MinMaxInit but with interface provided by funcs.
+func MinMaxInitF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
+	for i := Len/2 - 1; i >= 0; i-- {
+		minMaxDown(Len, Less, Swap, i)
+	}
+}
+
+// MinMaxPushLast pushes the last element of the min-max heap, which was not
+// considered as part of the heap, onto the heap.
+// The complexity is O(log(N)), where N = h.Len().
+//
+// NOTE You need to append the element to be pushed as the last element before
+// calling to this method.
+func MinMaxPushLast(h sort.Interface) {
+	minMaxUp(h.Less, h.Swap, h.Len()-1)
+}
+
+// Similar to MinMaxPushLast but with interface provided by funcs.
+func MinMaxPushLastF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
+	minMaxUp(Less, Swap, Len-1)
+}
+
+// PopMinToLast removes the minimum element (according to Less) from the min-max
+// heap and place it as the last element of the heap.
+// The complexity is O(log(N)), where N = h.Len().
+//
+// NOTE You need to remove the last element after calling to this method.
+func PopMinToLast(h sort.Interface) {
+	PopMinToLastF(h.Len(), h.Less, h.Swap)
+}
+
+// Similar to PopMinToLast but with interface provided by funcs.
+func PopMinToLastF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
+	Swap(0, Len-1)
+	minMaxDown(Len-1, Less, Swap, 0)
+}
+
+// PopMaxToLast removes the maximum element (according to Less) from the min-max
+// heap and place it as the last element of the heap.
+// The complexity is O(log(N)), where N = h.Len().
+//
+// NOTE You need to remove the last element after calling to this method.
+func PopMaxToLast(h sort.Interface) {
+	PopMaxToLastF(h.Len(), h.Less, h.Swap)
+}
+
+// Similar to PopMaxToLast but with interface provided by funcs.
+func PopMaxToLastF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
+	m := minMaxMaxIndex(Len, Less)
+	if n1 := Len - 1; m != n1 {
+		Swap(m, n1)
+		minMaxDown(n1, Less, Swap, m)
+	}
+}
+
+// minMaxMaxIndex returns the index of the maximum element of a non-empty
+// min-max heap.
+func minMaxMaxIndex(n int, less func(i, j int) bool) int {
+	switch {
+	case n == 1:
+		return 0
+	case n == 2 || !less(1, 2):
+		return 1
+	}
+	return 2
+}
+
+// isMinLevel returns whether index i is on a min level, i.e., an even level, of
+// a min-max heap.
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+func minMaxUp(less func(i, j int) bool, swap func(i, j int), i int) {
+	if i <= 0 {
+		return
+	}
+	greater := func(i, j int) bool { return less(j, i) }
+	p := (i - 1) / 2 // p is the parent of i
+	if isMinLevel(i) {
+		if less(p, i) {
+			swap(i, p)
+			minMaxUpLevel(greater, swap, p)
+		} else {
+			minMaxUpLevel(less, swap, i)
+		}
+	} else {
+		if less(i, p) {
+			swap(i, p)
+			minMaxUpLevel(less, swap, p)
+		} else {
+			minMaxUpLevel(greater, swap, i)
+		}
+	}
+}
+
+// minMaxUpLevel moves element i upwards through its grandparents, which are on
+// the levels of the same kind.
+func minMaxUpLevel(less func(i, j int) bool, swap func(i, j int), i int) {
+	for i > 2 {
+		g := ((i-1)/2 - 1) / 2 // g is the grandparent of i
+		if !less(i, g) {
+			break
+		}
+		swap(i, g)
+		i = g // move two levels up
+	}
+}
+
+func minMaxDown(n int, less func(i, j int) bool, swap func(i, j int), i int) {
+	if isMinLevel(i) {
+		minMaxDownLevel(n, less, swap, i)
+	} else {
+		minMaxDownLevel(n, func(i, j int) bool { return less(j, i) }, swap, i)
+	}
+}
+
+// minMaxDownLevel moves element i downwards through its grandchildren, which
+// are on the levels of the same kind, according to less.
+func minMaxDownLevel(n int, less func(i, j int) bool, swap func(i, j int), i int) {
+	for {
+		l := 2*i + 1 // left child
+		if l >= n || l < 0 {
+			break
+		}
+		// Find m, the minimum among children and grandchildren.
+		m := l
+		if r := l + 1; r < n && less(r, m) {
+			m = r
+		}
+		for g, e := 2*l+1, 2*l+5; g < e && g < n; g++ {
+			if less(g, m) {
+				m = g
+			}
+		}
+		if !less(m, i) {
+			// h[i] <= h[m], already in order
+			break
+		}
+		swap(i, m)
+		if m <= l+1 {
+			// m is a child, whose subtree is in order.
+			break
+		}
+		if p := (m - 1) / 2; less(p, m) {
+			swap(m, p)
+		}
+		i = m // move two levels down
+	}
+}
+
+// MinMax is a double-ended heap for values of type T, where both the minimum
+// and the maximum element can be peeked and popped. Use NewMinMax or
+// NewMinMaxFunc to create an instance.
+//
+// The pointer to the zero value of MinMax[T] is a heap with the natural order
+// if T is a builtin integer, floating-point or string type.
+type MinMax[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	list []T
+	// Applied after an element is removed. nil means never shrinking.
+	shrink ShrinkPolicy
+
+	bound   *MinMax[T]
+	lessIdx func(i, j int) bool
+	swapIdx func(i, j int)
+}
+
+// NewMinMax returns a *MinMax[T] which orders values on the natural order and
+// has the initial capacity.
+func NewMinMax[T cmp.Ordered](cap int) *MinMax[T] {
+	return NewMinMaxFunc(cmp.Less[T], cap)
+}
+
+// NewMinMaxFunc returns a *MinMax[T] with a customized less func and the
+// initial capacity. If less is nil, the natural order of T is used.
+func NewMinMaxFunc[T any](less func(x, y T) bool, cap int) *MinMax[T] {
+	h := &MinMax[T]{less: less}
+	if cap > 0 {
+		h.list = make([]T, 0, cap)
+	}
+	return h
+}
+
+// Len returns the number of elements in the current heap.
+func (h *MinMax[T]) Len() int {
+	return len(h.list)
+}
+
+// Push inserts an element to the heap.
+func (h *MinMax[T]) Push(x T) {
+	h.list = append(h.list, x)
+
+	less, swap := h.funcs()
+	MinMaxPushLastF(len(h.list), less, swap)
+}
+
+// PeekMin returns the minimum element. It panics if the heap is empty.
+func (h *MinMax[T]) PeekMin() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	return h.list[0]
+}
+
+// TryPeekMin returns the minimum element and true, or false if the heap is
+// empty.
+func (h *MinMax[T]) TryPeekMin() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.PeekMin(), true
+}
+
+// PeekMax returns the maximum element. It panics if the heap is empty.
+func (h *MinMax[T]) PeekMax() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	less, _ := h.funcs()
+	return h.list[minMaxMaxIndex(len(h.list), less)]
+}
+
+// TryPeekMax returns the maximum element and true, or false if the heap is
+// empty.
+func (h *MinMax[T]) TryPeekMax() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.PeekMax(), true
+}
+
+// PopMin removes the minimum element from the heap and returns it. It panics if
+// the heap is empty.
+func (h *MinMax[T]) PopMin() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	less, swap := h.funcs()
+	PopMinToLastF(len(h.list), less, swap)
+
+	return h.removeLast()
+}
+
+// TryPopMin removes the minimum element from the heap and returns it and
+// true, or false if the heap is empty.
+func (h *MinMax[T]) TryPopMin() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.PopMin(), true
+}
+
+// PopMax removes the maximum element from the heap and returns it. It panics if
+// the heap is empty.
+func (h *MinMax[T]) PopMax() T {
+	if len(h.list) == 0 {
+		panic(errEmpty)
+	}
+	less, swap := h.funcs()
+	PopMaxToLastF(len(h.list), less, swap)
+
+	return h.removeLast()
+}
+
+// TryPopMax removes the maximum element from the heap and returns it and
+// true, or false if the heap is empty.
+func (h *MinMax[T]) TryPopMax() (T, bool) {
+	if len(h.list) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.PopMax(), true
+}
+
+// Each calls f on each element of the heap in the order of the underlying
+// array, until f returns false. The heap must not be modified in f.
+func (h *MinMax[T]) Each(f func(x T) bool) {
+	for _, x := range h.list {
+		if !f(x) {
+			break
+		}
+	}
+}
+
+// Values returns a copy of the underlying array of the heap, whose first
+// element is the minimum one.
+func (h *MinMax[T]) Values() []T {
+	return append([]T(nil), h.list...)
+}
+
+// Sorted returns all elements of the heap in ascending order, leaving the heap
+// unchanged.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h *MinMax[T]) Sorted() []T {
+	h.funcs()
+	return sortedCopy(h.list, h.less)
+}
+
+// Clone returns a copy of the heap with the same order, capacity and shrink
+// policy.
+// The complexity is O(N), where N = h.Len().
+func (h *MinMax[T]) Clone() *MinMax[T] {
+	c := &MinMax[T]{less: h.less, shrink: h.shrink}
+	if h.list != nil {
+		c.list = withCap(h.list, cap(h.list))
+	}
+	return c
+}
+
+// Reset removes all elements from the heap while keeping the underlying array
+// for reuse.
+func (h *MinMax[T]) Reset() {
+	clear(h.list) // remove the references in h.list
+	h.list = h.list[:0]
+}
+
+// Grow grows the capacity of the heap, if necessary, to guarantee space for
+// another n elements.
+func (h *MinMax[T]) Grow(n int) {
+	if n < 0 {
+		panic("heap: negative Grow count")
+	}
+	if len(h.list)+n > cap(h.list) {
+		h.list = withCap(h.list, len(h.list)+n)
+	}
+}
+
+// Shrink releases the unused capacity of the underlying array.
+func (h *MinMax[T]) Shrink() {
+	if len(h.list) < cap(h.list) {
+		h.list = withCap(h.list, len(h.list))
+	}
+}
+
+// SetShrinkPolicy sets the ShrinkPolicy applied after an element is removed by
+// PopMin, PopMax and their Try variants. nil, the default, means never
+// shrinking.
+func (h *MinMax[T]) SetShrinkPolicy(p ShrinkPolicy) {
+	h.shrink = p
+}
+
+// funcs returns the less and swap funcs on indexes of h.list.
+func (h *MinMax[T]) funcs() (less func(i, j int) bool, swap func(i, j int)) {
+	if h.bound != h {
+		if h.less == nil {
+			h.less = naturalLess[T]()
+		}
+		h.lessIdx = func(i, j int) bool {
+			return h.less(h.list[i], h.list[j])
+		}
+		h.swapIdx = func(i, j int) {
+			h.list[i], h.list[j] = h.list[j], h.list[i]
+		}
+		h.bound = h
+	}
+	return h.lessIdx, h.swapIdx
+}
+
+// removeLast removes the last element of h.list and returns it.
+func (h *MinMax[T]) removeLast() T {
+	n := len(h.list) - 1
+	res := h.list[n]
+	var zero T
+	h.list[n] = zero // remove the reference in h.list
+	h.list = h.list[:n]
+	if h.shrink != nil {
+		if c := h.shrink(n, cap(h.list)); c < cap(h.list) {
+			h.list = withCap(h.list, max(c, n))
+		}
+	}
+	return res
+}
@@ -0,0 +1,172 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestMinMaxInit(t *testing.T) {
+	l := sort.IntSlice{5, 9, 1, 3, 2, 8, 7, 4, 6}
+	MinMaxInit(l)
+	var res []int
+	for n := len(l); n > 0; n-- {
+		if n%2 == 1 {
+			PopMinToLast(l[:n])
+		} else {
+			PopMaxToLast(l[:n])
+		}
+		res = append(res, l[n-1])
+	}
+	assert.Equal(t, "res", res, []int{1, 9, 2, 8, 3, 7, 4, 6, 5})
+}
+
+func TestMinMaxF_Random(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 10, 100, 1000} {
+		var l []int
+		less := func(i, j int) bool { return l[i] < l[j] }
+		swap := func(i, j int) { l[i], l[j] = l[j], l[i] }
+
+		for i := 0; i < n; i++ {
+			l = append(l, rand.Intn(n))
+			MinMaxPushLastF(len(l), less, swap)
+		}
+		sorted := append([]int(nil), l...)
+		sort.Ints(sorted)
+		for lo, hi := 0, n-1; lo <= hi; {
+			if rand.Intn(2) == 0 {
+				PopMinToLastF(len(l), less, swap)
+				assert.Equal(t, "min", l[len(l)-1], sorted[lo])
+				lo++
+			} else {
+				PopMaxToLastF(len(l), less, swap)
+				assert.Equal(t, "max", l[len(l)-1], sorted[hi])
+				hi--
+			}
+			l = l[:len(l)-1]
+		}
+
+		l = make([]int, n)
+		for i := range l {
+			l[i] = rand.Int()
+		}
+		MinMaxInitF(len(l), less, swap)
+		last := -1
+		for len(l) > 0 {
+			PopMinToLastF(len(l), less, swap)
+			if cur := l[len(l)-1]; cur < last {
+				t.Errorf("%d should be larger than %d", cur, last)
+			} else {
+				last = cur
+			}
+			l = l[:len(l)-1]
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	var h MinMax[int]
+
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.Push(5)
+	assert.Equal(t, "PeekMax", h.PeekMax(), 5)
+	h.Push(2)
+	assert.Equal(t, "PeekMax", h.PeekMax(), 5)
+	h.Push(8)
+	h.Push(1)
+	h.Push(3)
+
+	assert.Equal(t, "len", h.Len(), 5)
+	assert.Equal(t, "PeekMin", h.PeekMin(), 1)
+	assert.Equal(t, "PeekMax", h.PeekMax(), 8)
+
+	res := []int{h.PopMax(), h.PopMin(), h.PopMax(), h.PopMin(), h.PopMax()}
+	assert.Equal(t, "res", res, []int{8, 1, 5, 2, 3})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestMinMax_CustomLess(t *testing.T) {
+	h := NewMinMaxFunc(func(x, y string) bool {
+		return len(x) < len(y)
+	}, 4)
+	h.Push("ccc")
+	h.Push("a")
+	h.Push("dddd")
+	h.Push("bb")
+
+	assert.Equal(t, "PopMin", h.PopMin(), "a")
+	assert.Equal(t, "PopMax", h.PopMax(), "dddd")
+	assert.Equal(t, "PopMin", h.PopMin(), "bb")
+	assert.Equal(t, "PopMax", h.PopMax(), "ccc")
+	assert.Equal(t, "h.list[0]", h.list[:1][0], "")
+}
+
+func TestMinMax_Empty(t *testing.T) {
+	var h MinMax[int]
+	_, ok := h.TryPeekMin()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPeekMax()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPopMin()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPopMax()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "PeekMax", recovered(func() { h.PeekMax() }), "heap: empty")
+	assert.Equal(t, "PopMin", recovered(func() { h.PopMin() }), "heap: empty")
+
+	h.Push(1)
+	h.Push(2)
+	v, ok := h.TryPopMax()
+	assert.Equal(t, "v", v, 2)
+	assert.True(t, "ok", ok)
+	v, ok = h.TryPeekMin()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+}
+
+func TestMinMax_Iterate(t *testing.T) {
+	var h MinMax[int]
+	for _, v := range []int{5, 2, 8, 1, 3} {
+		h.Push(v)
+	}
+	var each []int
+	h.Each(func(x int) bool {
+		each = append(each, x)
+		return true
+	})
+	assert.Equal(t, "each", each, h.Values())
+	assert.Equal(t, "PeekMin", each[0], 1)
+	assert.Equal(t, "Sorted", h.Sorted(), []int{1, 2, 3, 5, 8})
+	assert.Equal(t, "len", h.Len(), 5)
+}
+
+func TestMinMax_Capacity(t *testing.T) {
+	h := NewMinMax[int](0)
+	for _, v := range []int{5, 2, 8} {
+		h.Push(v)
+	}
+	c := h.Clone()
+	assert.Equal(t, "PopMax", c.PopMax(), 8)
+	assert.Equal(t, "len", h.Len(), 3)
+
+	h.Grow(10)
+	assert.Equal(t, "cap", cap(h.list), 13)
+	assert.Panic(t, "Grow", func() {
+		h.Grow(-1)
+	})
+	h.Shrink()
+	assert.Equal(t, "cap", cap(h.list), 3)
+
+	h.SetShrinkPolicy(func(len, cap int) int {
+		return 0
+	})
+	assert.Equal(t, "PopMin", h.PopMin(), 2)
+	assert.Equal(t, "cap", cap(h.list), 2)
+
+	h.Reset()
+	assert.Equal(t, "len", h.Len(), 0)
+	assert.Equal(t, "c.Sorted", c.Sorted(), []int{2, 5})
+}
@@ -0,0 +1,289 @@
+package heap
+
+import (
+	"cmp"
+)
+
+// PairingNode is an element in a Pairing heap, returned by PushNode.
+type PairingNode[T any] struct {
+	value   T
+	child   *PairingNode[T]
+	sibling *PairingNode[T]
+	// prev is the parent if this is the first child, the previous sibling
+	// otherwise.
+	prev *PairingNode[T]
+}
+
+// Value returns the value of the node.
+func (n *PairingNode[T]) Value() T {
+	return n.value
+}
+
+// Pairing is a pointer based heap, which supports melding two heaps in O(1)
+// time and decreasing the value of a node in O(1) amortized time. Push, Peek
+// and Meld are O(1), and Pop is O(log(N)) amortized. Use NewPairing or
+// NewPairingFunc to create an instance.
+//
+// The pointer to the zero value of Pairing[T] is a heap with the natural order
+// if T is a builtin integer, floating-point or string type.
+type Pairing[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	root *PairingNode[T]
+	n    int
+}
+
+// NewPairing returns a *Pairing[T] which orders values on the natural order.
+func NewPairing[T cmp.Ordered]() *Pairing[T] {
+	return NewPairingFunc(cmp.Less[T])
+}
+
+// NewPairingFunc returns a *Pairing[T] with a customized less func. If less is
+// nil, the natural order of T is used.
+func NewPairingFunc[T any](less func(x, y T) bool) *Pairing[T] {
+	return &Pairing[T]{less: less}
+}
+
+// Len returns the number of elements in the current heap.
+func (h *Pairing[T]) Len() int {
+	return h.n
+}
+
+// Push inserts an element to the heap.
+func (h *Pairing[T]) Push(x T) {
+	h.PushNode(x)
+}
+
+// PushNode inserts an element to the heap and returns its node, which can be
+// used for DecreaseKey later.
+func (h *Pairing[T]) PushNode(x T) *PairingNode[T] {
+	n := &PairingNode[T]{value: x}
+	h.root = h.meld(h.root, n)
+	h.n++
+	return n
+}
+
+// Peek returns the top most element. It panics if the heap is empty.
+func (h *Pairing[T]) Peek() T {
+	if h.root == nil {
+		panic(errEmpty)
+	}
+	return h.root.value
+}
+
+// TryPeek returns the top most element and true, or false if the heap is empty.
+func (h *Pairing[T]) TryPeek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.Peek(), true
+}
+
+// Pop removes the top element from the heap and returns it. It panics if the
+// heap is empty.
+func (h *Pairing[T]) Pop() T {
+	if h.root == nil {
+		panic(errEmpty)
+	}
+	r := h.root
+	h.root = h.combine(r.child)
+	h.n--
+
+	r.child = nil
+	return r.value
+}
+
+// TryPop removes the top element from the heap and returns it and true, or
+// false if the heap is empty.
+func (h *Pairing[T]) TryPop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.Pop(), true
+}
+
+// PopAll pops and returns all elements of the heap in reverse order.
+func (h *Pairing[T]) PopAll() []T {
+	res := make([]T, h.n)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = h.Pop()
+	}
+	return res
+}
+
+// Each calls f on each element of the heap in pre-order of the tree, starting
+// from the top most one, until f returns false. The heap must not be modified
+// in f.
+func (h *Pairing[T]) Each(f func(x T) bool) {
+	if h.root == nil {
+		return
+	}
+	stack := []*PairingNode[T]{h.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(n.value) {
+			return
+		}
+		if n.sibling != nil {
+			stack = append(stack, n.sibling)
+		}
+		if n.child != nil {
+			stack = append(stack, n.child)
+		}
+	}
+}
+
+// Values returns the elements of the heap in the order of Each.
+func (h *Pairing[T]) Values() []T {
+	res := make([]T, 0, h.n)
+	h.Each(func(x T) bool {
+		res = append(res, x)
+		return true
+	})
+	return res
+}
+
+// Sorted returns all elements of the heap in the order they would be popped,
+// leaving the heap unchanged.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h *Pairing[T]) Sorted() []T {
+	return sortedCopy(h.Values(), h.lessFunc())
+}
+
+// DecreaseKey changes the value of node n, which must be in the heap, to x,
+// which must not be greater than its current value.
+// The complexity is O(1) amortized.
+func (h *Pairing[T]) DecreaseKey(n *PairingNode[T], x T) {
+	if h.lessFunc()(n.value, x) {
+		panic("heap: DecreaseKey with a greater value")
+	}
+	n.value = x
+	if n == h.root {
+		return
+	}
+	// Cut the subtree of n and meld it with the root.
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev, n.sibling = nil, nil
+	h.root = h.meld(h.root, n)
+}
+
+// Meld moves all elements of other into h, leaving other empty. Both heaps
+// must have the same order.
+// The complexity is O(1).
+func (h *Pairing[T]) Meld(other *Pairing[T]) {
+	if other == h {
+		return
+	}
+	h.root = h.meld(h.root, other.root)
+	h.n += other.n
+	other.root, other.n = nil, 0
+}
+
+// Clone returns a copy of the heap with the same order. The nodes are copied,
+// so the nodes returned by PushNode on h are not in the copy.
+// The complexity is O(N), where N = h.Len().
+func (h *Pairing[T]) Clone() *Pairing[T] {
+	return &Pairing[T]{less: h.less, root: clonePairingTree(h.root), n: h.n}
+}
+
+// Reset removes all elements from the heap.
+func (h *Pairing[T]) Reset() {
+	h.root, h.n = nil, 0
+}
+
+// clonePairingTree returns a copy of the tree rooted at n.
+func clonePairingTree[T any](n *PairingNode[T]) *PairingNode[T] {
+	if n == nil {
+		return nil
+	}
+	res := &PairingNode[T]{value: n.value}
+	// Pairs of a source node and its copy, whose child and sibling are to be
+	// copied. Trees can be deep, so recursion is avoided.
+	type pair struct{ src, dst *PairingNode[T] }
+	stack := []pair{{n, res}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if c := p.src.child; c != nil {
+			p.dst.child = &PairingNode[T]{value: c.value, prev: p.dst}
+			stack = append(stack, pair{c, p.dst.child})
+		}
+		if sb := p.src.sibling; sb != nil {
+			p.dst.sibling = &PairingNode[T]{value: sb.value, prev: p.dst}
+			stack = append(stack, pair{sb, p.dst.sibling})
+		}
+	}
+	return res
+}
+
+func (h *Pairing[T]) lessFunc() func(x, y T) bool {
+	if h.less == nil {
+		h.less = naturalLess[T]()
+	}
+	return h.less
+}
+
+// meld melds two detached trees and returns the new root.
+func (h *Pairing[T]) meld(a, b *PairingNode[T]) *PairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.lessFunc()(b.value, a.value) {
+		a, b = b, a
+	}
+	// b becomes the first child of a.
+	b.prev, b.sibling = a, a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// combine melds a list of siblings into a single tree with the two-pass
+// method and returns the root.
+func (h *Pairing[T]) combine(first *PairingNode[T]) *PairingNode[T] {
+	// Meld pairs from left to right, accumulating the results in reverse
+	// order linked by sibling.
+	var acc *PairingNode[T]
+	for c := first; c != nil; {
+		a, b := c, c.sibling
+		if b == nil {
+			c = nil
+		} else {
+			c = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+
+		m := h.meld(a, b)
+		m.sibling = acc
+		acc = m
+	}
+	if acc == nil {
+		return nil
+	}
+	// Meld the results from right to left.
+	root, rest := acc, acc.sibling
+	root.sibling = nil
+	for rest != nil {
+		next := rest.sibling
+		rest.sibling = nil
+		root = h.meld(root, rest)
+		rest = next
+	}
+	return root
+}
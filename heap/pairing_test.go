@@ -0,0 +1,197 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestPairing(t *testing.T) {
+	var h Pairing[int]
+
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+
+	assert.Equal(t, "len", h.Len(), 4)
+	assert.Equal(t, "peek", h.Peek(), 1)
+
+	res := []int{h.Pop(), h.Pop(), h.Pop(), h.Pop()}
+	assert.Equal(t, "res", res, []int{1, 2, 3, 5})
+
+	h.Push(5)
+	h.Push(2)
+	h.Push(1)
+	h.Push(3)
+	assert.Equal(t, "PopAll", h.PopAll(), []int{5, 3, 2, 1})
+	assert.Equal(t, "len", h.Len(), 0)
+}
+
+func TestPairing_DecreaseKey(t *testing.T) {
+	h := NewPairing[int]()
+	nodes := make([]*PairingNode[int], 100)
+	for i := range nodes {
+		nodes[i] = h.PushNode(1000 + i)
+	}
+	h.Pop()
+	for i := range nodes {
+		if i > 0 && i%3 == 0 {
+			h.DecreaseKey(nodes[i], i)
+			assert.Equal(t, "Value", nodes[i].Value(), i)
+		}
+	}
+	assert.Panic(t, "DecreaseKey", func() {
+		h.DecreaseKey(nodes[1], 2000)
+	})
+	for i := 3; i < 100; i += 3 {
+		assert.Equal(t, "Pop", h.Pop(), i)
+	}
+	assert.Equal(t, "Peek", h.Peek(), 1001)
+	assert.Equal(t, "len", h.Len(), 66)
+}
+
+func TestPairing_Meld(t *testing.T) {
+	h1 := NewPairingFunc(func(x, y string) bool {
+		return x > y
+	})
+	h1.Push("a")
+	h1.Push("c")
+	h2 := NewPairingFunc(func(x, y string) bool {
+		return x > y
+	})
+	h2.Push("d")
+	h2.Push("b")
+
+	h1.Meld(h2)
+	assert.Equal(t, "h1.Len()", h1.Len(), 4)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+	h1.Meld(h1)
+	assert.Equal(t, "h1.Len()", h1.Len(), 4)
+	assert.Equal(t, "PopAll", h1.PopAll(), []string{"a", "b", "c", "d"})
+}
+
+func TestPairing_Random(t *testing.T) {
+	var h Pairing[int]
+	var expected []int
+	for i := 0; i < 1000; i++ {
+		if rand.Intn(3) == 0 && h.Len() > 0 {
+			sort.Ints(expected)
+			assert.Equal(t, "Pop", h.Pop(), expected[0])
+			expected = expected[1:]
+		} else {
+			v := rand.Intn(100)
+			h.Push(v)
+			expected = append(expected, v)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(expected)))
+	assert.Equal(t, "PopAll", h.PopAll(), expected)
+}
+
+func TestPairing_StandsInForInterfaces(t *testing.T) {
+	type heap interface {
+		Len() int
+		Push(x interface{})
+		Pop() interface{}
+		PopAll() []interface{}
+		Peek() interface{}
+	}
+	var _ heap = NewInterfaces(nil, 0)
+
+	var h heap = NewPairingFunc(func(x, y interface{}) bool {
+		return x.(int) < y.(int)
+	})
+	h.Push(2)
+	h.Push(1)
+	assert.Equal(t, "Peek", h.Peek(), 1)
+	assert.Equal(t, "PopAll", h.PopAll(), []interface{}{2, 1})
+}
+
+func BenchmarkPairingIntHeap(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h Pairing[int]
+		for _, vl := range data {
+			h.Push(vl)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func TestPairing_Empty(t *testing.T) {
+	var h Pairing[int]
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	_, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), "heap: empty")
+
+	h.Push(1)
+	v, ok := h.TryPop()
+	assert.Equal(t, "v", v, 1)
+	assert.True(t, "ok", ok)
+}
+
+func TestPairing_Iterate(t *testing.T) {
+	var h Pairing[int]
+	for _, v := range []int{5, 2, 8, 1, 3} {
+		h.Push(v)
+	}
+	h.Pop()
+	h.Push(1)
+
+	var each []int
+	h.Each(func(x int) bool {
+		each = append(each, x)
+		return len(each) < 2
+	})
+	assert.Equal(t, "each", each, []int{1, 2})
+	values := h.Values()
+	assert.Equal(t, "Values[0]", values[0], 1)
+	sort.Ints(values)
+	assert.Equal(t, "Values", values, []int{1, 2, 3, 5, 8})
+	assert.Equal(t, "Sorted", h.Sorted(), []int{1, 2, 3, 5, 8})
+	assert.Equal(t, "PopAll", h.PopAll(), []int{8, 5, 3, 2, 1})
+}
+
+func TestPairing_Clone(t *testing.T) {
+	h := NewPairing[int]()
+	for i := 0; i < 100; i++ {
+		h.Push(rand.Intn(1000))
+	}
+	h.Pop() // builds deeper trees
+	for i := 0; i < 50; i++ {
+		h.Push(rand.Intn(1000))
+	}
+	exp := h.Sorted()
+
+	c := h.Clone()
+	assert.Equal(t, "len", c.Len(), h.Len())
+	assert.Equal(t, "PopAll", c.PopAll(), reversed(exp))
+	assert.Equal(t, "Sorted", h.Sorted(), exp)
+
+	h.Reset()
+	assert.Equal(t, "len", h.Len(), 0)
+	_, ok := h.TryPeek()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "empty Clone", NewPairing[int]().Clone().Len(), 0)
+}
+
+func reversed(s []int) []int {
+	res := make([]int, len(s))
+	for i, x := range s {
+		res[len(s)-1-i] = x
+	}
+	return res
+}
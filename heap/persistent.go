@@ -0,0 +1,181 @@
+package heap
+
+import (
+	"cmp"
+)
+
+type leftistNode[T any] struct {
+	value       T
+	left, right *leftistNode[T]
+	// The length of the right spine, which is not greater than that of left.
+	rank int
+	// The number of nodes in the subtree.
+	size int
+}
+
+func (n *leftistNode[T]) getRank() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+// Persistent is an immutable heap implemented as a leftist heap. Push, Pop and
+// Merge return new versions of the heap sharing most nodes with the original
+// ones, which stay valid and unchanged, so forking a heap is O(1). Push, Pop and
+// Merge are O(log(N)) and Peek is O(1). Use NewPersistent or NewPersistentFunc
+// to create an instance.
+//
+// The zero value of Persistent[T] is an empty heap with the natural order if T
+// is a builtin integer, floating-point or string type. A Persistent value is
+// safe for concurrent use by multiple goroutines.
+type Persistent[T any] struct {
+	// Less func on values. nil means the natural order of T.
+	less func(x, y T) bool
+	root *leftistNode[T]
+}
+
+// NewPersistent returns an empty Persistent[T] which orders values on the
+// natural order.
+func NewPersistent[T cmp.Ordered]() Persistent[T] {
+	return NewPersistentFunc(cmp.Less[T])
+}
+
+// NewPersistentFunc returns an empty Persistent[T] with a customized less func.
+// If less is nil, the natural order of T is used.
+func NewPersistentFunc[T any](less func(x, y T) bool) Persistent[T] {
+	return Persistent[T]{less: less}
+}
+
+// Len returns the number of elements in the heap.
+func (h Persistent[T]) Len() int {
+	if h.root == nil {
+		return 0
+	}
+	return h.root.size
+}
+
+// Push returns a new heap with x inserted to h.
+// The complexity is O(log(N)), where N = h.Len().
+func (h Persistent[T]) Push(x T) Persistent[T] {
+	h.root = h.merge(h.lessFunc(), h.root, &leftistNode[T]{value: x, rank: 1, size: 1})
+	return h
+}
+
+// Peek returns the top most element. It panics if the heap is empty.
+func (h Persistent[T]) Peek() T {
+	if h.root == nil {
+		panic(errEmpty)
+	}
+	return h.root.value
+}
+
+// TryPeek returns the top most element and true, or false if the heap is empty.
+func (h Persistent[T]) TryPeek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.value, true
+}
+
+// Pop returns the top element and a new heap with it removed from h. It panics
+// if the heap is empty.
+// The complexity is O(log(N)), where N = h.Len().
+func (h Persistent[T]) Pop() (T, Persistent[T]) {
+	if h.root == nil {
+		panic(errEmpty)
+	}
+	x := h.root.value
+	h.root = h.merge(h.lessFunc(), h.root.left, h.root.right)
+	return x, h
+}
+
+// TryPop returns the top element, a new heap with it removed from h and true,
+// or false if the heap is empty.
+func (h Persistent[T]) TryPop() (T, Persistent[T], bool) {
+	if h.root == nil {
+		var zero T
+		return zero, h, false
+	}
+	x, h := h.Pop()
+	return x, h, true
+}
+
+// Merge returns a new heap with all elements of h and other. Both heaps must
+// have the same order.
+// The complexity is O(log(N+M)), where N = h.Len() and M = other.Len().
+func (h Persistent[T]) Merge(other Persistent[T]) Persistent[T] {
+	h.root = h.merge(h.lessFunc(), h.root, other.root)
+	return h
+}
+
+// Each calls f on each element of the heap in pre-order of the tree, starting
+// from the top most one, until f returns false.
+func (h Persistent[T]) Each(f func(x T) bool) {
+	if h.root == nil {
+		return
+	}
+	stack := []*leftistNode[T]{h.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !f(n.value) {
+			return
+		}
+		if n.right != nil {
+			stack = append(stack, n.right)
+		}
+		if n.left != nil {
+			stack = append(stack, n.left)
+		}
+	}
+}
+
+// Values returns the elements of the heap in the order of Each.
+func (h Persistent[T]) Values() []T {
+	res := make([]T, 0, h.Len())
+	h.Each(func(x T) bool {
+		res = append(res, x)
+		return true
+	})
+	return res
+}
+
+// Sorted returns all elements of the heap in the order they would be popped.
+// The complexity is O(N*log(N)), where N = h.Len().
+func (h Persistent[T]) Sorted() []T {
+	return sortedCopy(h.Values(), h.lessFunc())
+}
+
+func (h Persistent[T]) lessFunc() func(x, y T) bool {
+	if h.less == nil {
+		return naturalLess[T]()
+	}
+	return h.less
+}
+
+// merge returns the root of a new tree with all nodes of trees a and b, copying
+// the nodes on the right spines and sharing the others.
+func (h Persistent[T]) merge(less func(x, y T) bool, a, b *leftistNode[T]) *leftistNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if less(b.value, a.value) {
+		a, b = b, a
+	}
+	l, r := a.left, h.merge(less, a.right, b)
+	if l.getRank() < r.rank {
+		l, r = r, l
+	}
+	return &leftistNode[T]{
+		value: a.value,
+		left:  l,
+		right: r,
+		rank:  r.getRank() + 1,
+		size:  a.size + b.size,
+	}
+}
@@ -0,0 +1,102 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestPersistent(t *testing.T) {
+	var h0 Persistent[int]
+
+	assert.Equal(t, "len", h0.Len(), 0)
+	_, ok := h0.TryPeek()
+	assert.False(t, "ok", ok)
+	_, _, ok = h0.TryPop()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h0.Pop() }), errEmpty)
+
+	h1 := h0.Push(5).Push(2).Push(1)
+	h2 := h1.Push(3)
+	assert.Equal(t, "h0.len", h0.Len(), 0)
+	assert.Equal(t, "h1.len", h1.Len(), 3)
+	assert.Equal(t, "h2.len", h2.Len(), 4)
+	assert.Equal(t, "peek", h2.Peek(), 1)
+
+	x, h3 := h2.Pop()
+	assert.Equal(t, "x", x, 1)
+	assert.Equal(t, "h3", h3.Sorted(), []int{2, 3, 5})
+	// Older versions are unchanged.
+	assert.Equal(t, "h2", h2.Sorted(), []int{1, 2, 3, 5})
+	assert.Equal(t, "h1", h1.Sorted(), []int{1, 2, 5})
+
+	x, h4, ok := h3.TryPop()
+	assert.True(t, "ok", ok)
+	assert.Equal(t, "x", x, 2)
+	assert.Equal(t, "h4", h4.Sorted(), []int{3, 5})
+}
+
+func TestPersistent_Merge(t *testing.T) {
+	h1 := NewPersistentFunc(func(x, y int) bool { return x > y })
+	h2 := h1
+	for i := 0; i < 10; i++ {
+		h1 = h1.Push(2 * i)
+		h2 = h2.Push(2*i + 1)
+	}
+	h := h1.Merge(h2)
+	assert.Equal(t, "len", h.Len(), 20)
+	var res []int
+	for h.Len() > 0 {
+		var x int
+		x, h = h.Pop()
+		res = append(res, x)
+	}
+	assert.Equal(t, "res", res, []int{19, 18, 17, 16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0})
+	assert.Equal(t, "h1.len", h1.Len(), 10)
+	assert.Equal(t, "h2.len", h2.Len(), 10)
+	assert.Equal(t, "merge empty", h1.Merge(Persistent[int]{}).Len(), 10)
+}
+
+func TestPersistent_Fork(t *testing.T) {
+	// Every version is checked against a sorted slice after random forks.
+	type version struct {
+		h   Persistent[int]
+		exp []int
+	}
+	versions := []version{{h: NewPersistent[int]()}}
+	for i := 0; i < 2000; i++ {
+		v := versions[rand.Intn(len(versions))]
+		if len(v.exp) > 0 && rand.Intn(3) == 0 {
+			x, h := v.h.Pop()
+			assert.Equal(t, "Pop", x, v.exp[0])
+			versions = append(versions, version{h, v.exp[1:]})
+			continue
+		}
+		x := rand.Intn(1000)
+		exp := append(append([]int(nil), v.exp...), x)
+		sort.Ints(exp)
+		versions = append(versions, version{v.h.Push(x), exp})
+	}
+	for _, v := range versions {
+		assert.Equal(t, "Sorted", v.h.Sorted(), append([]int{}, v.exp...))
+	}
+}
+
+func BenchmarkPersistent(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := NewPersistent[int]()
+		for _, vl := range data {
+			h = h.Push(vl)
+		}
+		for h.Len() > 0 {
+			_, h = h.Pop()
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package heap
+
+import (
+	"math"
+	"math/bits"
+)
+
+type radixItem[V any] struct {
+	key   uint64
+	value V
+}
+
+// RadixHeap is a monotone priority queue keyed by uint64, where a pushed key
+// must not be less than the key last popped, e.g., the distances in Dijkstra's
+// algorithm or event times in a simulation. Push is O(1) and Pop is O(log(C))
+// amortized, where C is the range of the keys.
+//
+// The zero value of RadixHeap is an empty heap ready to use.
+type RadixHeap[V any] struct {
+	// buckets[0] holds items whose key equals last. buckets[i] (i > 0) holds
+	// items whose key differs from last first at bit i-1.
+	buckets [65][]radixItem[V]
+	// The key last popped. Every key in the heap is not less than last.
+	last uint64
+	n    int
+	// If peekBucket > 0, buckets[peekBucket][peekIndex] is the element Peek
+	// returns while buckets[0] is empty. Peek does not redistribute the
+	// buckets, which would move last and reject keys legal to push.
+	peekBucket, peekIndex int
+}
+
+// Len returns the number of elements in the current heap.
+func (h *RadixHeap[V]) Len() int {
+	return h.n
+}
+
+// Push inserts value with key to the heap. It panics if key is less than the
+// key last popped.
+func (h *RadixHeap[V]) Push(key uint64, value V) {
+	if key < h.last {
+		panic("heap: RadixHeap key less than the last popped key")
+	}
+	b := radixBucket(key, h.last)
+	h.buckets[b] = append(h.buckets[b], radixItem[V]{key: key, value: value})
+	h.n++
+	if h.peekBucket > 0 && key <= h.buckets[h.peekBucket][h.peekIndex].key {
+		h.peekBucket = 0
+	}
+}
+
+// Peek returns the minimum key and its value. It panics if the heap is empty.
+func (h *RadixHeap[V]) Peek() (uint64, V) {
+	if h.n == 0 {
+		panic(errEmpty)
+	}
+	if b := h.buckets[0]; len(b) > 0 {
+		item := b[len(b)-1]
+		return item.key, item.value
+	}
+	if h.peekBucket == 0 {
+		i := h.firstBucket()
+		// The last one of the minimum key, which is popped first after
+		// redistributing.
+		k := 0
+		for j, item := range h.buckets[i] {
+			if item.key <= h.buckets[i][k].key {
+				k = j
+			}
+		}
+		h.peekBucket, h.peekIndex = i, k
+	}
+	item := h.buckets[h.peekBucket][h.peekIndex]
+	return item.key, item.value
+}
+
+// TryPeek returns the minimum key, its value and true, or false if the heap is
+// empty.
+func (h *RadixHeap[V]) TryPeek() (uint64, V, bool) {
+	if h.n == 0 {
+		var zero V
+		return 0, zero, false
+	}
+	key, value := h.Peek()
+	return key, value, true
+}
+
+// Pop removes the element with the minimum key from the heap and returns the
+// key and its value. It panics if the heap is empty.
+func (h *RadixHeap[V]) Pop() (uint64, V) {
+	h.pull()
+	b := h.buckets[0]
+	item := b[len(b)-1]
+	b[len(b)-1] = radixItem[V]{} // remove the reference in the bucket
+	h.buckets[0] = b[:len(b)-1]
+	h.n--
+	return item.key, item.value
+}
+
+// TryPop removes the element with the minimum key from the heap and returns
+// the key, its value and true, or false if the heap is empty.
+func (h *RadixHeap[V]) TryPop() (uint64, V, bool) {
+	if h.n == 0 {
+		var zero V
+		return 0, zero, false
+	}
+	key, value := h.Pop()
+	return key, value, true
+}
+
+// pull makes buckets[0] non-empty by redistributing the first non-empty
+// bucket around its minimum key.
+func (h *RadixHeap[V]) pull() {
+	if h.n == 0 {
+		panic(errEmpty)
+	}
+	if len(h.buckets[0]) > 0 {
+		return
+	}
+	i := h.firstBucket()
+	b := h.buckets[i]
+	min := uint64(math.MaxUint64)
+	for _, item := range b {
+		if item.key < min {
+			min = item.key
+		}
+	}
+	h.last = min
+	// All items in b go to buckets lower than i.
+	for _, item := range b {
+		j := radixBucket(item.key, h.last)
+		h.buckets[j] = append(h.buckets[j], item)
+	}
+	clear(b) // remove the references in b
+	h.buckets[i] = b[:0]
+	h.peekBucket = 0
+}
+
+// firstBucket returns the index of the first non-empty bucket other than
+// buckets[0].
+func (h *RadixHeap[V]) firstBucket() int {
+	i := 1
+	for len(h.buckets[i]) == 0 {
+		i++
+	}
+	return i
+}
+
+// radixBucket returns the bucket index of key relative to last.
+func radixBucket(key, last uint64) int {
+	return bits.Len64(key ^ last)
+}
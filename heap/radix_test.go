@@ -0,0 +1,260 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestRadixHeap(t *testing.T) {
+	var h RadixHeap[string]
+	assert.Equal(t, "len", h.Len(), 0)
+
+	h.Push(5, "e")
+	h.Push(2, "b")
+	h.Push(9, "i")
+	h.Push(2, "B")
+
+	assert.Equal(t, "len", h.Len(), 4)
+	k, v := h.Peek()
+	assert.Equal(t, "k", k, uint64(2))
+
+	k, v = h.Pop()
+	assert.Equal(t, "k", k, uint64(2))
+	k, _ = h.Pop()
+	assert.Equal(t, "k", k, uint64(2))
+
+	h.Push(3, "c")
+	assert.Panic(t, "Push", func() {
+		h.Push(1, "a")
+	})
+	k, v = h.Pop()
+	assert.Equal(t, "k", k, uint64(3))
+	assert.Equal(t, "v", v, "c")
+	k, v, ok := h.TryPeek()
+	assert.Equal(t, "k", k, uint64(5))
+	assert.Equal(t, "v", v, "e")
+	assert.True(t, "ok", ok)
+	k, v, ok = h.TryPop()
+	assert.Equal(t, "k", k, uint64(5))
+	assert.True(t, "ok", ok)
+	k, v = h.Pop()
+	assert.Equal(t, "k", k, uint64(9))
+	assert.Equal(t, "v", v, "i")
+
+	_, _, ok = h.TryPop()
+	assert.False(t, "ok", ok)
+	_, _, ok = h.TryPeek()
+	assert.False(t, "ok", ok)
+	assert.Equal(t, "Pop", recovered(func() { h.Pop() }), "heap: empty")
+}
+
+func TestRadixHeap_Peek(t *testing.T) {
+	var h RadixHeap[string]
+	h.Push(10, "a")
+	h.Pop()
+	h.Push(20, "b")
+	k, _ := h.Peek()
+	assert.Equal(t, "k", k, uint64(20))
+	// Peek does not change the last popped key.
+	h.Push(15, "c")
+	k, v := h.Peek()
+	assert.Equal(t, "k", k, uint64(15))
+	assert.Equal(t, "v", v, "c")
+	h.Push(10, "d")
+	assert.Panic(t, "Push", func() {
+		h.Push(9, "e")
+	})
+
+	// Peek returns the element Pop pops among those of the same key.
+	h.Push(10, "f")
+	h.Push(12, "g")
+	k, v = h.Peek()
+	for _, exp := range []string{"f", "d", "c", "b"} {
+		assert.Equal(t, "Peek", v, exp)
+		pk, pv := h.Pop()
+		assert.Equal(t, "k", pk, k)
+		assert.Equal(t, "v", pv, v)
+		if exp == "d" {
+			h.Pop() // g
+		}
+		if h.Len() > 0 {
+			k, v = h.Peek()
+		}
+	}
+}
+
+func TestRadixHeap_Random(t *testing.T) {
+	var h RadixHeap[int]
+	var expected []uint64
+	var last uint64
+	for i := 0; i < 10000; i++ {
+		if rand.Intn(3) == 0 && h.Len() > 0 {
+			sort.Slice(expected, func(i, j int) bool { return expected[i] < expected[j] })
+			pk, pv := h.Peek()
+			k, v := h.Pop()
+			assert.Equal(t, "Peek", pk, k)
+			assert.Equal(t, "Peek", pv, v)
+			assert.Equal(t, "k", k, expected[0])
+			assert.Equal(t, "v", uint64(v), k)
+			expected, last = expected[1:], k
+		} else {
+			k := last + uint64(rand.Int63n(1<<uint(rand.Intn(63))))
+			h.Push(k, int(k))
+			expected = append(expected, k)
+		}
+	}
+}
+
+type graphEdge struct {
+	to, weight int
+}
+
+// randomGraph returns a random connected graph of n nodes as adjacency lists.
+func randomGraph(n, degree int) [][]graphEdge {
+	r := rand.New(rand.NewSource(1))
+	g := make([][]graphEdge, n)
+	for u := 1; u < n; u++ {
+		v := r.Intn(u)
+		w := r.Intn(1000)
+		g[u] = append(g[u], graphEdge{v, w})
+		g[v] = append(g[v], graphEdge{u, w})
+	}
+	for i := 0; i < n*(degree-1); i++ {
+		u := r.Intn(n)
+		g[u] = append(g[u], graphEdge{r.Intn(n), r.Intn(1000)})
+	}
+	return g
+}
+
+// dijkstraRadix returns the distances from node 0 with a RadixHeap and lazy
+// deletion.
+func dijkstraRadix(g [][]graphEdge) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	var h RadixHeap[int]
+	h.Push(0, 0)
+	for h.Len() > 0 {
+		d, u := h.Pop()
+		if dist[u] >= 0 {
+			continue
+		}
+		dist[u] = int(d)
+		for _, e := range g[u] {
+			if dist[e.to] < 0 {
+				h.Push(d+uint64(e.weight), e.to)
+			}
+		}
+	}
+	return dist
+}
+
+type distNode struct {
+	dist, node int
+}
+
+// dijkstraHeap returns the distances from node 0 with a Heap and lazy
+// deletion.
+func dijkstraHeap(g [][]graphEdge) []int {
+	dist := make([]int, len(g))
+	for i := range dist {
+		dist[i] = -1
+	}
+	h := NewFunc(func(x, y distNode) bool {
+		return x.dist < y.dist
+	}, 0)
+	h.Push(distNode{0, 0})
+	for h.Len() > 0 {
+		x := h.Pop()
+		if dist[x.node] >= 0 {
+			continue
+		}
+		dist[x.node] = x.dist
+		for _, e := range g[x.node] {
+			if dist[e.to] < 0 {
+				h.Push(distNode{x.dist + e.weight, e.to})
+			}
+		}
+	}
+	return dist
+}
+
+const graphN = 10000
+
+func TestDijkstraRadix(t *testing.T) {
+	g := randomGraph(1000, 4)
+	assert.Equal(t, "dist", dijkstraRadix(g), dijkstraHeap(g))
+}
+
+func BenchmarkRadixHeap(b *testing.B) {
+	var data [M]uint64
+	for i := range data {
+		data[i] = uint64(rand.Int63())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h RadixHeap[struct{}]
+		for _, vl := range data {
+			h.Push(vl, struct{}{})
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func BenchmarkRadixHeap_Monotone(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Intn(1000)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h RadixHeap[struct{}]
+		for _, vl := range data {
+			h.Push(uint64(vl), struct{}{})
+		}
+		for _, vl := range data {
+			k, _ := h.Pop()
+			h.Push(k+uint64(vl), struct{}{})
+		}
+	}
+}
+
+func BenchmarkRadixHeap_MonotoneInts(b *testing.B) {
+	var data [M]int
+	for i := range data {
+		data[i] = rand.Intn(1000)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var h Ints
+		for _, vl := range data {
+			h.Push(vl)
+		}
+		for _, vl := range data {
+			k := h.Pop()
+			h.Push(k + vl)
+		}
+	}
+}
+
+func BenchmarkDijkstra_Radix(b *testing.B) {
+	g := randomGraph(graphN, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraRadix(g)
+	}
+}
+
+func BenchmarkDijkstra_Heap(b *testing.B) {
+	g := randomGraph(graphN, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dijkstraHeap(g)
+	}
+}
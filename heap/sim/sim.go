@@ -0,0 +1,188 @@
+// Package sim provides a scheduler for discrete-event simulations, which runs
+// timestamped events in the order of their times on a virtual clock. Events of
+// the same time run in the order they were scheduled, so a simulation is
+// deterministic across runs.
+//
+// A Scheduler is not safe for concurrent use. Events are expected to be
+// scheduled and canceled by the simulation itself, i.e., in the funcs of the
+// events.
+package sim
+
+import (
+	"time"
+
+	"github.com/golangplus/container/heap"
+)
+
+// EventID identifies a scheduled event. IDs are never reused by a Scheduler.
+type EventID uint64
+
+// Event describes a scheduled event.
+type Event struct {
+	ID EventID
+	// The virtual time when the event runs.
+	At time.Duration
+}
+
+type event struct {
+	Event
+	fn func()
+	// The index in Scheduler.events.
+	index int
+}
+
+// Scheduler runs events on a virtual clock, which starts at zero and advances
+// to the time of each event when it runs.
+//
+// The zero value of Scheduler is an empty scheduler ready to use.
+type Scheduler struct {
+	now time.Duration
+	// A heap of the pending events ordered by At and then ID.
+	events []*event
+	byID   map[EventID]*event
+	lastID EventID
+	trace  func(e Event)
+
+	bound   *Scheduler
+	lessIdx func(i, j int) bool
+	swapIdx func(i, j int)
+}
+
+// Now returns the current virtual time.
+func (s *Scheduler) Now() time.Duration {
+	return s.now
+}
+
+// Len returns the number of pending events.
+func (s *Scheduler) Len() int {
+	return len(s.events)
+}
+
+// Next returns the pending event to run next and true, or false if there are no
+// pending events.
+func (s *Scheduler) Next() (Event, bool) {
+	if len(s.events) == 0 {
+		return Event{}, false
+	}
+	return s.events[0].Event, true
+}
+
+// SetTrace sets a func called with each event right before it runs, e.g., for
+// tracing a simulation. nil disables it.
+func (s *Scheduler) SetTrace(f func(e Event)) {
+	s.trace = f
+}
+
+// Schedule schedules fn to run at the virtual time at and returns the ID of the
+// event. It panics if at is before the current virtual time.
+// The complexity is O(log(N)), where N = s.Len().
+func (s *Scheduler) Schedule(at time.Duration, fn func()) EventID {
+	if at < s.now {
+		panic("sim: scheduling an event in the past")
+	}
+	if s.byID == nil {
+		s.byID = make(map[EventID]*event)
+	}
+	s.lastID++
+	e := &event{Event: Event{ID: s.lastID, At: at}, fn: fn, index: len(s.events)}
+	s.events = append(s.events, e)
+	s.byID[e.ID] = e
+
+	less, swap := s.funcs()
+	heap.PushLastF(len(s.events), less, swap)
+	return e.ID
+}
+
+// After schedules fn to run after d from the current virtual time and returns
+// the ID of the event.
+func (s *Scheduler) After(d time.Duration, fn func()) EventID {
+	return s.Schedule(s.now+d, fn)
+}
+
+// Cancel cancels a pending event and returns true, or returns false if the
+// event has run or been canceled.
+// The complexity is O(log(N)), where N = s.Len().
+func (s *Scheduler) Cancel(id EventID) bool {
+	e, ok := s.byID[id]
+	if !ok {
+		return false
+	}
+	less, swap := s.funcs()
+	heap.RemoveToLastF(len(s.events), less, swap, e.index)
+	s.removeLast()
+	return true
+}
+
+// Step advances the virtual clock to the time of the next event and runs it.
+// It returns false if there are no pending events.
+func (s *Scheduler) Step() bool {
+	if len(s.events) == 0 {
+		return false
+	}
+	less, swap := s.funcs()
+	heap.PopToLastF(len(s.events), less, swap)
+	e := s.removeLast()
+
+	s.now = e.At
+	if s.trace != nil {
+		s.trace(e.Event)
+	}
+	e.fn()
+	return true
+}
+
+// RunUntil runs the events up to and including the virtual time t in order,
+// including those scheduled during the run, and then advances the virtual
+// clock to t if it is later. It returns the number of events run.
+func (s *Scheduler) RunUntil(t time.Duration) int {
+	n := 0
+	for len(s.events) > 0 && s.events[0].At <= t {
+		s.Step()
+		n++
+	}
+	if t > s.now {
+		s.now = t
+	}
+	return n
+}
+
+// Run runs all events, including those scheduled during the run, until there
+// are no pending events. It returns the number of events run.
+func (s *Scheduler) Run() int {
+	n := 0
+	for s.Step() {
+		n++
+	}
+	return n
+}
+
+// funcs returns the less and swap funcs on indexes of s.events. swap keeps the
+// indexes in the events in sync.
+func (s *Scheduler) funcs() (less func(i, j int) bool, swap func(i, j int)) {
+	if s.bound != s {
+		s.lessIdx = func(i, j int) bool {
+			ei, ej := s.events[i], s.events[j]
+			if ei.At != ej.At {
+				return ei.At < ej.At
+			}
+			return ei.ID < ej.ID
+		}
+		s.swapIdx = func(i, j int) {
+			s.events[i], s.events[j] = s.events[j], s.events[i]
+			s.events[i].index = i
+			s.events[j].index = j
+		}
+		s.bound = s
+	}
+	return s.lessIdx, s.swapIdx
+}
+
+// removeLast removes the last event of s.events and returns it.
+func (s *Scheduler) removeLast() *event {
+	n := len(s.events) - 1
+	e := s.events[n]
+	s.events[n] = nil // remove the reference in s.events
+	s.events = s.events[:n]
+	delete(s.byID, e.ID)
+	return e
+}
@@ -0,0 +1,118 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestScheduler(t *testing.T) {
+	var s Scheduler
+	var log []string
+	record := func(name string) func() {
+		return func() {
+			log = append(log, fmt.Sprintf("%v %s", s.Now(), name))
+		}
+	}
+	s.Schedule(3*time.Second, record("c"))
+	s.Schedule(1*time.Second, record("a1"))
+	s.Schedule(1*time.Second, record("a2"))
+	s.Schedule(2*time.Second, func() {
+		record("b")()
+		// Scheduled during the run, and at the current time.
+		s.After(0, record("b-now"))
+		s.After(time.Second, record("b+1"))
+	})
+	assert.Equal(t, "len", s.Len(), 4)
+	next, ok := s.Next()
+	assert.True(t, "ok", ok)
+	assert.Equal(t, "next", next, Event{ID: 2, At: time.Second})
+
+	assert.Equal(t, "RunUntil", s.RunUntil(2*time.Second), 4)
+	assert.Equal(t, "now", s.Now(), 2*time.Second)
+	assert.Equal(t, "RunUntil", s.RunUntil(10*time.Second), 2)
+	assert.Equal(t, "now", s.Now(), 10*time.Second)
+	assert.Equal(t, "log", log, []string{
+		"1s a1", "1s a2", "2s b", "2s b-now", "3s c", "3s b+1",
+	})
+
+	_, ok = s.Next()
+	assert.False(t, "ok", ok)
+	assert.False(t, "Step", s.Step())
+
+	assert.Equal(t, "Schedule", func() (res interface{}) {
+		defer func() { res = recover() }()
+		s.Schedule(time.Second, func() {})
+		return nil
+	}(), "sim: scheduling an event in the past")
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	var s Scheduler
+	var ran []int
+	ids := make([]EventID, 10)
+	for i := range ids {
+		i := i
+		ids[i] = s.Schedule(time.Duration(i%3)*time.Second, func() {
+			ran = append(ran, i)
+		})
+	}
+	assert.True(t, "Cancel", s.Cancel(ids[4]))
+	assert.False(t, "Cancel", s.Cancel(ids[4]))
+	assert.True(t, "Cancel", s.Cancel(ids[0]))
+	assert.Equal(t, "len", s.Len(), 8)
+
+	assert.Equal(t, "Run", s.Run(), 8)
+	assert.Equal(t, "ran", ran, []int{3, 6, 9, 1, 7, 2, 5, 8})
+	assert.False(t, "Cancel", s.Cancel(ids[1]))
+}
+
+func TestScheduler_Trace(t *testing.T) {
+	var s Scheduler
+	var trace []Event
+	s.SetTrace(func(e Event) {
+		trace = append(trace, e)
+	})
+	a := s.Schedule(2, func() {})
+	b := s.Schedule(1, func() {})
+	s.Run()
+	assert.Equal(t, "trace", trace, []Event{{b, 1}, {a, 2}})
+}
+
+// run runs a random simulation with a seed and returns the trace.
+func run(seed int64) []Event {
+	r := rand.New(rand.NewSource(seed))
+	var s Scheduler
+	var trace []Event
+	s.SetTrace(func(e Event) {
+		trace = append(trace, e)
+	})
+	var ids []EventID
+	var spawn func()
+	spawn = func() {
+		for i := r.Intn(3); i > 0; i-- {
+			ids = append(ids, s.After(time.Duration(r.Intn(5)), spawn))
+		}
+		if len(ids) > 0 && r.Intn(4) == 0 {
+			s.Cancel(ids[r.Intn(len(ids))])
+		}
+	}
+	for i := 0; i < 100; i++ {
+		s.Schedule(time.Duration(r.Intn(10)), spawn)
+	}
+	s.RunUntil(100)
+	return trace
+}
+
+func TestScheduler_Deterministic(t *testing.T) {
+	trace := run(1)
+	assert.Equal(t, "trace", run(1), trace)
+	for i := 1; i < len(trace); i++ {
+		if p, e := trace[i-1], trace[i]; e.At < p.At || e.At == p.At && e.ID < p.ID {
+			t.Fatalf("event %v runs after %v", e, p)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package heap
+
+import (
+	"sort"
+)
+
+// Sort sorts the data defined by the sort.Interface in ascending order with
+// heapsort. It is in-place, allocation free and takes O(N*log(N)) in the worst
+// case, where N = h.Len(). The sort is not stable.
+func Sort(h sort.Interface) {
+	SortF(h.Len(), h.Less, h.Swap)
+}
+
+// Similar to Sort but with interface provided by funcs.
+func SortF(Len int, Less func(i, j int) bool, Swap func(i, j int)) {
+	greater := func(i, j int) bool { return Less(j, i) }
+	// Build a max-heap and move the maximum to the end repeatedly.
+	InitF(Len, greater, Swap)
+	for n := Len; n > 1; n-- {
+		PopToLastF(n, greater, Swap)
+	}
+}
+
+// PartialSort puts the k smallest elements of the data defined by the
+// sort.Interface in ascending order at the front. The order of the remaining
+// elements is unspecified. It is in-place, allocation free and takes
+// O(N*log(k)), where N = h.Len().
+//
+// If k >= N, it is the same as Sort.
+func PartialSort(h sort.Interface, k int) {
+	PartialSortF(h.Len(), h.Less, h.Swap, k)
+}
+
+// Similar to PartialSort but with interface provided by funcs.
+func PartialSortF(Len int, Less func(i, j int) bool, Swap func(i, j int), k int) {
+	if k > Len {
+		k = Len
+	}
+	if k <= 0 {
+		return
+	}
+	greater := func(i, j int) bool { return Less(j, i) }
+	// Keep the k smallest elements in a max-heap at the front.
+	InitF(k, greater, Swap)
+	for i := k; i < Len; i++ {
+		if Less(i, 0) {
+			Swap(0, i)
+			heapDown(k, greater, Swap, 0)
+		}
+	}
+	for n := k; n > 1; n-- {
+		PopToLastF(n, greater, Swap)
+	}
+}
@@ -0,0 +1,79 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestSort(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 10, 1000} {
+		l := make(sort.IntSlice, n)
+		for i := range l {
+			l[i] = rand.Intn(n + 1)
+		}
+		expected := append([]int{}, l...)
+		sort.Ints(expected)
+
+		Sort(l)
+		assert.Equal(t, "l", []int(l), expected)
+	}
+
+	l := []string{"Elmo", "Big Bird", "Abby", "Count"}
+	SortF(len(l), func(i, j int) bool { return l[i] > l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] })
+	assert.Equal(t, "l", l, []string{"Elmo", "Count", "Big Bird", "Abby"})
+}
+
+func TestPartialSort(t *testing.T) {
+	for _, k := range []int{-1, 0, 1, 5, 100, 200} {
+		l := make(sort.IntSlice, 100)
+		for i := range l {
+			l[i] = rand.Intn(50)
+		}
+		expected := append([]int{}, l...)
+		sort.Ints(expected)
+
+		PartialSort(l, k)
+		if k < 0 {
+			k = 0
+		} else if k > len(l) {
+			k = len(l)
+		}
+		assert.Equal(t, "l[:k]", []int(l[:k]), expected[:k])
+		rest := append([]int{}, l[k:]...)
+		sort.Ints(rest)
+		assert.Equal(t, "l[k:]", rest, expected[k:])
+	}
+
+	l := []int{5, 9, 1, 3, 2}
+	PartialSortF(len(l), func(i, j int) bool { return l[i] > l[j] }, func(i, j int) { l[i], l[j] = l[j], l[i] }, 2)
+	assert.Equal(t, "l[:2]", l[:2], []int{9, 5})
+}
+
+func BenchmarkSort(b *testing.B) {
+	data := make(sort.IntSlice, M)
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	l := make(sort.IntSlice, M)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(l, data)
+		Sort(l)
+	}
+}
+
+func BenchmarkSort_Builtin(b *testing.B) {
+	data := make(sort.IntSlice, M)
+	for i := range data {
+		data[i] = rand.Int()
+	}
+	l := make(sort.IntSlice, M)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(l, data)
+		sort.Sort(l)
+	}
+}
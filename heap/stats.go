@@ -0,0 +1,82 @@
+package heap
+
+import (
+	"math/bits"
+)
+
+// Stats is a snapshot of the counters of a StatsCollector.
+type Stats struct {
+	// The number of calls to the less func.
+	Less int
+	// The number of calls to the swap func.
+	Swap int
+	// The maximum depth, in a binary heap, of the indexes passed to the less
+	// and swap funcs. The root is at depth 0.
+	MaxDepth int
+	// The maximum size of the heap observed.
+	PeakSize int
+}
+
+// StatsCollector counts the operations on heaps, e.g., to tell whether a hot
+// path is bound by comparisons or swaps. Use Wrap for the F functions, or
+// Heap.SetStats for the typed heaps. The zero value is a collector ready to
+// use. It is not safe for concurrent use.
+type StatsCollector struct {
+	stats Stats
+}
+
+// Stats returns a snapshot of the counters.
+func (c *StatsCollector) Stats() Stats {
+	return c.stats
+}
+
+// Reset resets all counters to zero.
+func (c *StatsCollector) Reset() {
+	c.stats = Stats{}
+}
+
+// Wrap returns the less and swap funcs counting their calls into c. The
+// returned funcs can be passed to the F functions, such as PushLastF and
+// PopToLastF. Since the F functions do not know the size of the heap, PeakSize
+// is inferred from the largest index passed to the funcs.
+//
+// If c is nil, less and swap are returned unchanged, so that a disabled
+// collector costs nothing.
+func (c *StatsCollector) Wrap(less func(i, j int) bool, swap func(i, j int)) (func(i, j int) bool, func(i, j int)) {
+	return c.wrap(less, swap, true)
+}
+
+// wrap is similar to Wrap, and infers PeakSize from the indexes only if
+// inferSize is true. The typed heaps know their sizes, which may differ, e.g.,
+// by the temporary slot used by TopNPush.
+func (c *StatsCollector) wrap(less func(i, j int) bool, swap func(i, j int), inferSize bool) (func(i, j int) bool, func(i, j int)) {
+	if c == nil {
+		return less, swap
+	}
+	return func(i, j int) bool {
+			c.stats.Less++
+			c.touch(max(i, j), inferSize)
+			return less(i, j)
+		}, func(i, j int) {
+			c.stats.Swap++
+			c.touch(max(i, j), inferSize)
+			swap(i, j)
+		}
+}
+
+// touch records an index passed to the less or swap func.
+func (c *StatsCollector) touch(i int, inferSize bool) {
+	if inferSize {
+		c.observeSize(i + 1)
+	}
+	if d := bits.Len(uint(i+1)) - 1; d > c.stats.MaxDepth {
+		c.stats.MaxDepth = d
+	}
+}
+
+// observeSize records the size of a heap.
+func (c *StatsCollector) observeSize(n int) {
+	if n > c.stats.PeakSize {
+		c.stats.PeakSize = n
+	}
+}
@@ -0,0 +1,107 @@
+package heap
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/golangplus/testing/assert"
+)
+
+func TestStatsCollector_Wrap(t *testing.T) {
+	var c StatsCollector
+	var h sort.IntSlice
+	less, swap := c.Wrap(func(i, j int) bool {
+		return h.Less(i, j)
+	}, func(i, j int) {
+		h.Swap(i, j)
+	})
+	for _, x := range []int{7, 6, 5, 4, 3, 2, 1} {
+		h = append(h, x)
+		PushLastF(len(h), less, swap)
+	}
+	// Every element moves up to the root.
+	assert.Equal(t, "stats", c.Stats(), Stats{
+		Less:     10,
+		Swap:     10,
+		MaxDepth: 2,
+		PeakSize: 7,
+	})
+	assert.Equal(t, "h[0]", h[0], 1)
+
+	c.Reset()
+	assert.Equal(t, "stats", c.Stats(), Stats{})
+
+	// A nil collector returns the funcs unchanged.
+	var nc *StatsCollector
+	less, swap = nc.Wrap(h.Less, h.Swap)
+	PopToLastF(len(h), less, swap)
+	assert.Equal(t, "h[6]", h[6], 1)
+	assert.Equal(t, "stats", c.Stats(), Stats{})
+}
+
+func TestHeap_SetStats(t *testing.T) {
+	var c StatsCollector
+	var h Ints
+	h.SetStats(&c)
+	h.Push(1)
+	assert.Equal(t, "stats", c.Stats(), Stats{PeakSize: 1})
+
+	for _, x := range []int{2, 3, 4} {
+		h.Push(x)
+	}
+	// No element moves up.
+	assert.Equal(t, "stats", c.Stats(), Stats{
+		Less:     3,
+		MaxDepth: 2,
+		PeakSize: 4,
+	})
+
+	assert.Equal(t, "Pop", h.Pop(), 1)
+	s := c.Stats()
+	assert.Equal(t, "swap", s.Swap, 2)
+	assert.Equal(t, "PeakSize", s.PeakSize, 4)
+
+	// A copy of the heap keeps the collector, but a clone does not.
+	h2 := h
+	h2.Push(0)
+	assert.Equal(t, "PeakSize", c.Stats().PeakSize, 4)
+	assert.True(t, "swap", c.Stats().Swap > 2)
+
+	c.Reset()
+	h3 := h.Clone()
+	h3.Pop()
+	assert.Equal(t, "stats", c.Stats(), Stats{})
+
+	h.SetStats(nil)
+	h.Pop()
+	assert.Equal(t, "stats", c.Stats(), Stats{})
+}
+
+func TestHeap_SetStatsChecked(t *testing.T) {
+	// Checking in checked mode is not counted.
+	var stats []Stats
+	for _, checked := range []bool{false, true} {
+		var c StatsCollector
+		var h Ints
+		h.SetStats(&c)
+		h.SetChecked(checked)
+		for i := 0; i < 100; i++ {
+			h.Push(i)
+		}
+		stats = append(stats, c.Stats())
+	}
+	assert.Equal(t, "Less", stats[0].Less, 99)
+	assert.Equal(t, "stats", stats[1], stats[0])
+}
+
+func TestHeap_SetStatsTopN(t *testing.T) {
+	// The temporary slot of TopNPush is not counted in PeakSize.
+	var c StatsCollector
+	h := NewInts(nil, 3)
+	h.SetStats(&c)
+	for i := 0; i < 10; i++ {
+		h.TopNPush(i)
+	}
+	assert.Equal(t, "PeakSize", c.Stats().PeakSize, 3)
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []int{9, 8, 7})
+}
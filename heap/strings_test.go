@@ -66,3 +66,40 @@ func TestStrings_CustomLess(t *testing.T) {
 	h.Push("Elmo")
 	assert.Equal(t, "PopAll", h.PopAll(), []string{"Abby", "Elmo", "Big Bird", "Count"})
 }
+
+func TestStrings_Meld(t *testing.T) {
+	var h1, h2 Strings
+	h1.Push("Elmo")
+	h1.Push("Abby")
+	h2.Push("Count")
+	h1.Meld(&h2)
+	assert.Equal(t, "h2.Len()", h2.Len(), 0)
+	assert.Equal(t, "h2.list[0]", h2.list[:1][0], "")
+	assert.Equal(t, "PopAll", h1.PopAll(), []string{"Elmo", "Count", "Abby"})
+}
+
+func TestStrings_TopN(t *testing.T) {
+	h := NewStrings(nil, 2)
+	for _, v := range []string{"Elmo", "Big Bird", "Abby", "Count"} {
+		h.TopNPush(v)
+	}
+	assert.Equal(t, "TopNPopAll", h.TopNPopAll(), []string{"Elmo", "Count"})
+	assert.Equal(t, "h.list[0]", h.list[:1][0], "")
+
+	assert.Equal(t, "TopNStrings", TopNStrings([]string{"Elmo", "Big Bird", "Abby", "Count"}, 2, func(x, y string) bool {
+		return len(x) < len(y)
+	}), []string{"Big Bird", "Count"})
+}
+
+func TestStrings_Iterate(t *testing.T) {
+	h := NewStrings(func(x, y string) bool {
+		return x > y
+	}, 0)
+	h.Push("Abby")
+	h.Push("Elmo")
+	h.Push("Count")
+
+	assert.Equal(t, "Values", h.Values(), []string{"Elmo", "Abby", "Count"})
+	assert.Equal(t, "Sorted", h.Sorted(), []string{"Elmo", "Count", "Abby"})
+	assert.Equal(t, "PopAll", h.PopAll(), []string{"Abby", "Count", "Elmo"})
+}